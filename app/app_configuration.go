@@ -2,18 +2,119 @@ package app
 
 import (
 	"github.com/sirupsen/logrus"
+	"github.com/vkrava4/curlson/load"
+	"github.com/vkrava4/curlson/stats"
 	"os"
+	"text/template"
+	"time"
 )
 
 type Configuration struct {
-	Logs     *LogConfiguration
-	Template *TemplateConfiguration
+	Logs       *LogConfiguration
+	Template   *TemplateConfiguration
+	Request    *RequestConfiguration
+	Load       *LoadConfiguration
+	Thresholds []*stats.Threshold
 }
 
 type TemplateConfiguration struct {
 	Enabled bool
-	Path    string
-	Size    int
+	Seed    int64
+
+	// Sources holds validated template files: an unnamed one provided by 'template-file' flag and named data sources
+	Sources []*TemplateSourceConfiguration
+}
+
+type TemplateSourceConfiguration struct {
+	Name string
+	Path string
+	Size int
+	Mode string
+
+	Type      string
+	Delimiter rune
+	Header    bool
+}
+
+type RequestConfiguration struct {
+	Method        string
+	Body          []byte
+	BodyTemplated bool
+	ContentType   string
+	Headers       []Header
+
+	// GoTemplate holds compiled URL, headers and body templates when Go template engine is used
+	GoTemplate *template.Template
+
+	Timeouts  TimeoutConfiguration
+	Transport TransportConfiguration
+}
+
+// TransportConfiguration describes how connections of requests are established and reused
+type TransportConfiguration struct {
+	// DisableKeepAlives disables HTTP keep-alive, so servers are asked to close a connection after every response
+	DisableKeepAlives bool
+
+	// Pool is a way connections are pooled: by all threads together or by every thread separately
+	Pool string
+
+	// MaxConnectionsPerHost is a maximum number of connections of a pool to a single host, zero means no limit
+	MaxConnectionsPerHost int
+
+	// IdleTimeout is a time an idle connection is kept in a pool, zero means no limit
+	IdleTimeout time.Duration
+
+	// NewConnectionPerRequest makes every request use a new connection which is closed once the response is read.
+	// It disables HTTP keep-alive just like DisableKeepAlives does
+	NewConnectionPerRequest bool
+}
+
+// TimeoutConfiguration holds timeouts of a single request, zero means that there is no timeout
+type TimeoutConfiguration struct {
+	// Connect is a timeout of establishing a TCP connection
+	Connect time.Duration
+
+	// TLS is a timeout of a TLS handshake
+	TLS time.Duration
+
+	// ResponseHeader is a timeout of waiting for response headers once a request is written
+	ResponseHeader time.Duration
+
+	// Total is a timeout of the whole request including reading of the response body
+	Total time.Duration
+}
+
+// LoadConfiguration describes how requests are scheduled
+type LoadConfiguration struct {
+	// Rate is a number of requests per second of a constant arrival rate (open model). Zero means that every thread
+	// sends its requests back-to-back (closed model)
+	Rate float64
+
+	// Stages is a load profile with linearly interpolated targets, its targets are numbers of concurrent threads
+	// or rates in requests per second or maximum rates of requests depending on StageType. No stages means that the load is constant
+	Stages    []load.Stage
+	StageType string
+
+	// Duration is a duration of a run during which threads send requests regardless of a request count.
+	// Zero means that a run lasts until all requests are sent
+	Duration time.Duration
+
+	// ThinkTime is a pause of every thread after each request, nil means that requests are sent back-to-back
+	ThinkTime *load.ThinkTime
+
+	// MaxRate is a maximum number of requests per second sent by all threads together, zero means that the rate
+	// is not limited. MaxRateBurst is a number of requests which can be sent at once after a period of inactivity
+	MaxRate      float64
+	MaxRateBurst int
+
+	// ControlAddress is an address of a control endpoint which changes the maximum rate during a run,
+	// empty means that the endpoint is disabled
+	ControlAddress string
+}
+
+type Header struct {
+	Name  string
+	Value string
 }
 
 type LogConfiguration struct {
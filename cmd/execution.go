@@ -0,0 +1,864 @@
+/*
+Copyright © 2020 Vlad Krava <vkrava4@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/fatih/color"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/vkrava4/curlson/app"
+	"github.com/vkrava4/curlson/faker"
+	"github.com/vkrava4/curlson/load"
+	"github.com/vkrava4/curlson/report"
+	"github.com/vkrava4/curlson/stats"
+	"github.com/vkrava4/curlson/ui"
+	"github.com/vkrava4/curlson/util"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ExitCodeThresholdsFailed is an exit code of the application when any of thresholds failed
+const ExitCodeThresholdsFailed = 99
+
+// ExitCodeInterrupted is an exit code of the application when execution is interrupted by a signal, it takes precedence
+// over ExitCodeThresholdsFailed since thresholds of an interrupted execution are evaluated against completed requests only
+const ExitCodeInterrupted = 130
+
+var count int
+var sleepMs int
+var threads int
+var maxDuration int
+var template string
+var templateMode string
+var seed int64
+var templateType string
+var templateEngine string
+var dataSources []string
+var dataModes []string
+var rate string
+var stages []string
+var stageType string
+var duration string
+var thinkTime string
+var maxRate string
+var maxRateBurst int
+var controlAddress string
+var gracePeriod time.Duration
+var connectTimeout string
+var tlsTimeout string
+var responseHeaderTimeout string
+var maxTime string
+var keepAlive = true
+var connectionPool string
+var maxConnectionsPerHost int
+var idleTimeout string
+var newConnectionPerRequest = false
+var templateDelimiter string
+var templateHeader bool
+var output string
+var outputFormat string
+var rawOutput string
+var htmlReport string
+var thresholds []string
+var headers []string
+var headersFile string
+var data string
+var contentType string
+var persistLogs = false
+var verbose = false
+
+var appConf = &app.Configuration{}
+
+// configFileFlags are names of flags of request timeouts and connections which can be also set in the config file
+var configFileFlags = []string{"connect-timeout", "tls-timeout", "response-header-timeout", "max-time",
+	"keep-alive", "connection-pool", "max-connections-per-host", "idle-timeout", "new-connection-per-request"}
+
+// httpClients send requests of threads by their IDs applying timeouts and transport options of the request configuration
+var httpClients []*http.Client
+
+// rateLimiter limits a rate of requests sent by all threads together when 'max-rate' is set, nil otherwise
+var rateLimiter *load.Limiter
+
+// requestSequence is a global sequence number of requests used by '#F{seq}' template function
+var requestSequence int64
+
+var yellowColor = color.New(color.FgYellow)
+
+// addExecutionFlags defines flags which are common for every command performing requests.
+// A given method is used in flags descriptions only
+func addExecutionFlags(cmd *cobra.Command, method string) {
+	cmd.Flags().IntVarP(&threads, "threads", "t", 1, fmt.Sprintf("A number of concurrent %s requests", method))
+	cmd.Flags().IntVarP(&count, "count", "c", 1, fmt.Sprintf("A number of %s requests per single thread or a total number of requests when 'rate' is set", method))
+	cmd.Flags().IntVarP(&sleepMs, "sleep", "s", 0, fmt.Sprintf("A delay in millis after each %s requests. Doesn't impact performance report results if set (default 0)", method))
+	cmd.Flags().StringVar(&thinkTime, "think-time", "", "A random pause of every thread after each request which imitates real users: '250ms' (fixed), '100ms..500ms' (uniform), "+
+		"'normal:300ms,50ms' (mean and standard deviation), 'exponential:300ms' or 'poisson:300ms' (mean) or 'template:key' (a template value in millis or a duration like '1.5s'). "+
+		"Think time is excluded from latency and reported separately")
+	cmd.Flags().IntVarP(&maxDuration, "duration-max", "D", 0, "A maximum duration in seconds by reaching which requests execution will be terminated regardless of a 'count' flag value. When the value set to '0' this flag is ignored (default 0)")
+	cmd.Flags().StringVar(&duration, "duration", "", "A duration of execution, e.g. '30s' or '5m', during which threads send requests regardless of a 'count' flag value. "+
+		"Requests which are still in flight when the duration is over are cancelled and not reported")
+	cmd.Flags().StringVar(&rate, "rate", "", "A constant arrival rate of requests in a form of 'count/period', e.g. '500/s', '30/m' or '5/100ms' (open model). "+
+		"Requests are sent on a fixed timetable regardless of response times by a pool of 'threads' workers, requests which can't be sent "+
+		"on time because all workers are busy are reported as late dispatches. Latencies are measured from scheduled send times")
+	cmd.Flags().StringArrayVar(&stages, "stage", nil, "A stage of a load profile in a form of 'duration:target', e.g. '30s:10'. A target changes linearly from a target "+
+		"of the previous stage (or zero for the first stage) over the stage duration, so '--stage 30s:10 --stage 2m:200 --stage 30s:0' ramps up, ramps further and ramps down. "+
+		"Execution lasts for a total duration of stages and 'count' is ignored. Can be repeated")
+	cmd.Flags().StringVar(&stageType, "stage-type", load.StageTypeThreads, "A type of stage targets: 'threads' (a number of concurrent threads sending requests back-to-back) "+
+		"'rate' (requests per second sent on a fixed timetable by a pool of 'threads' workers) or 'max-rate' (a maximum rate in requests per second of 'threads' threads)")
+	cmd.Flags().StringVar(&maxRate, "max-rate", "", "A maximum rate of requests sent by all threads together in a form of 'count/period', e.g. '200/s'. "+
+		"Every thread waits for a token of a shared token bucket before sending a request, so the rate never exceeds the limit regardless of a number of threads")
+	cmd.Flags().IntVar(&maxRateBurst, "max-rate-burst", 1, "A number of requests which can be sent at once above 'max-rate' after a period of inactivity")
+	cmd.Flags().StringVar(&controlAddress, "control-address", "", "An address of a control endpoint, e.g. '127.0.0.1:6060', which changes 'max-rate' during a run. "+
+		"'GET /max-rate' returns the current max rate and 'PUT /max-rate' with a body like '300/s' changes it")
+	cmd.Flags().DurationVar(&gracePeriod, "grace-period", 5*time.Second, "A time to wait for in-flight requests once execution is interrupted by SIGINT (Ctrl+C) or SIGTERM. "+
+		"No new requests are sent after the first signal and the summary covers completed requests, the second signal terminates the application immediately. "+
+		"Interrupted execution exits with code 130")
+	cmd.Flags().StringVar(&connectTimeout, "connect-timeout", "30s", "A maximum time to establish a connection as a duration like '2s' or a number of seconds. "+
+		"'0' means no timeout. Can be also set with 'connect-timeout' in the config file")
+	cmd.Flags().StringVar(&tlsTimeout, "tls-timeout", "10s", "A maximum time of a TLS handshake as a duration like '2s' or a number of seconds. "+
+		"'0' means no timeout. Can be also set with 'tls-timeout' in the config file")
+	cmd.Flags().StringVar(&responseHeaderTimeout, "response-header-timeout", "0", "A maximum time to wait for response headers once a request is sent as a duration like '2s' "+
+		"or a number of seconds. '0' means no timeout. Can be also set with 'response-header-timeout' in the config file")
+	cmd.Flags().StringVar(&maxTime, "max-time", "0", "A maximum time of the whole request including reading of the response body as a duration like '2s' "+
+		"or a number of seconds. '0' means no timeout. Can be also set with 'max-time' in the config file. "+
+		"Timed out requests are reported with a separate error class of every timeout")
+	cmd.Flags().BoolVar(&keepAlive, "keep-alive", true, "A flag which defines whether connections are kept alive and reused by subsequent requests. "+
+		"Use '--keep-alive=false' to ask servers to close a connection after every response, such requests are sent over HTTP/1.1. "+
+		"Can be also set with 'keep-alive' in the config file")
+	cmd.Flags().StringVar(&connectionPool, "connection-pool", util.ConnectionPoolShared, "A pool of kept alive connections: 'shared' (any thread reuses any idle connection) "+
+		"or 'per-thread' (every thread reuses only its own connections like separate clients do). Requests of 'per-thread' pools are sent over HTTP/1.1. "+
+		"Can be also set with 'connection-pool' in the config file")
+	cmd.Flags().IntVar(&maxConnectionsPerHost, "max-connections-per-host", 0, "A maximum number of connections of a pool to a single host, requests wait for a free connection "+
+		"once the limit is reached. When the value set to '0' connections are not limited. Can be also set with 'max-connections-per-host' in the config file (default 0)")
+	cmd.Flags().StringVar(&idleTimeout, "idle-timeout", "90s", "A time an idle connection is kept in a pool as a duration like '30s' or a number of seconds. '0' means no limit. "+
+		"Can be also set with 'idle-timeout' in the config file")
+	cmd.Flags().BoolVar(&newConnectionPerRequest, "new-connection-per-request", false, "A flag which defines whether every request opens a new connection which is closed "+
+		"once the response is read. It's the same as '--keep-alive=false', so servers are asked to close a connection and requests are sent over HTTP/1.1. "+
+		"A ratio of requests sent over reused connections is reported in the summary. Can be also set with 'new-connection-per-request' in the config file")
+	cmd.Flags().StringVarP(&template, "template-file", "T", "", "")
+	cmd.Flags().StringVar(&templateMode, "template-mode", util.TemplateModeRandom, "A way template lines are picked: 'random', 'sequential' (in order with a cursor shared by all threads), "+
+		"'per-thread' (lines are partitioned across threads) or 'once' (every line is used exactly once, execution stops when lines are exhausted)")
+	cmd.Flags().StringVar(&templateEngine, "template-engine", util.TemplateEngineCurlson, "A template engine of URL, headers and body: 'curlson' ('#T{key}' placeholders and '#F{...}' functions) "+
+		"or 'go' (Go text/template with '.Row', '.ThreadID', '.Iteration', '.Seq' and '.Vars' in the data context and every '#F{...}' function available as '{{name args}}')")
+	cmd.Flags().StringArrayVar(&dataSources, "data-source", nil, "A named data source in a form of 'name=path' joined with the template file. Its values are referenced by "+
+		"placeholders prefixed with the source name, e.g. '#T{users.id}' or '#T{products.sku}'. Can be repeated. "+
+		"Every line of a data source is validated along with the first lines of the template file and other data sources only. "+
+		"The flag is not called 'data' as '-d/--data' already sets a request body")
+	cmd.Flags().StringArrayVar(&dataModes, "data-mode", nil, "A way lines of a named data source are picked in a form of 'name=mode', e.g. 'users=per-thread'. "+
+		"By default every data source uses 'template-mode'. Can be repeated")
+	cmd.Flags().StringVar(&templateType, "template-type", "", "A type of a template file: 'csv' or 'jsonl' (one JSON object per line, its fields are referenced by dotted paths like '#T{user.address.zip}' or '#T{orders.0.id}'). "+
+		"By default it's 'jsonl' for '.jsonl' and '.ndjson' files and 'csv' otherwise")
+	cmd.Flags().StringVar(&templateDelimiter, "template-delimiter", string(util.DefaultTemplateDelimiter), "A delimiter of values of template lines which are parsed as CSV records. Use '\\t' or 'tab' for tab-separated values")
+	cmd.Flags().BoolVar(&templateHeader, "template-header", false, "A flag which defines whether the first line of a template file holds column names which can be referenced by placeholders like '#T{user_id}'")
+	cmd.Flags().Int64Var(&seed, "seed", 0, "A seed of 'random' template mode, random and fake values of template functions to make runs reproducible. "+
+		"When the value set to '0' a random seed is used and printed in verbose mode (default 0)")
+	cmd.Flags().StringArrayVarP(&headers, "header", "H", nil, "An extra header in a form of 'Name: value' to include in requests. Can be repeated. "+
+		"A value may contain the same '#T{key}' and '#TE{key}' placeholders as URL which are filled from the same template line and '#F{...}' template functions")
+	cmd.Flags().StringVar(&headersFile, "headers-file", "", "A file with extra headers in a form of 'Name: value', one per line. Empty lines and lines starting with '#' are ignored")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "A file path to which the run summary will be written once execution is completed")
+	cmd.Flags().StringVarP(&outputFormat, "format", "f", report.FormatJSON, "A format of the run summary written to 'output' file: json or csv")
+	cmd.Flags().StringVar(&rawOutput, "raw-output", "", "A file path to which a result of every single request will be streamed. The file is written as JSON Lines if its extension is '.jsonl' or '.json' and as CSV otherwise")
+	cmd.Flags().StringVar(&htmlReport, "html-report", "", "A file path to which a self-contained HTML report with charts will be written once execution is completed")
+	cmd.Flags().StringArrayVar(&thresholds, "threshold", nil, "A pass/fail criteria evaluated against the final statistics, e.g. 'p95<300ms', 'error_rate<1%' or 'rps>500'. "+
+		"Can be repeated. When any threshold fails the command exits with code 99. Can be also set with 'thresholds' list in the config file")
+	cmd.Flags().BoolVarP(&persistLogs, "persist-logs", "p", false, "A flag which defines whether execution log files will be persisted or automatically cleaned up")
+	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "A flag which defines whether additional execution information such as log creations or other actions will be logged in console output")
+}
+
+// addBodyFlags defines flags of commands which send a request body
+func addBodyFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&data, "data", "d", "", "A request body. Use '@file' to read the body from a file or '@-' to read it from the standard input. "+
+		"The body may contain template placeholders '#T{key}', '#TE{key}' (URL-encoded), '#TJ{key}' (JSON string escaped) and '#F{...}' template functions")
+	cmd.Flags().StringVar(&contentType, "content-type", "", fmt.Sprintf("A content type of the request body (default '%s' when 'data' is set)", util.DefaultBodyContentType))
+}
+
+// prepareExecution initializes logs configuration and applies values from the config file
+// to execution flags which were not set explicitly
+func prepareExecution(cmd *cobra.Command) {
+	appConf.Logs = &app.LogConfiguration{
+		Enabled: false,
+		Persist: persistLogs,
+		Verbose: verbose,
+		Log:     logrus.New(),
+	}
+
+	if !cmd.Flags().Changed("threshold") {
+		thresholds = viper.GetStringSlice("thresholds")
+	}
+
+	for _, name := range configFileFlags {
+		if !cmd.Flags().Changed(name) && viper.IsSet(name) {
+			_ = cmd.Flags().Set(name, viper.GetString(name))
+		}
+	}
+}
+
+// validateAndExecute validates execution flags, performs requests with a given HTTP method against a given address
+// and processes thresholds. This is a common flow of every command performing requests
+func validateAndExecute(cmd *cobra.Command, method string, url string) {
+	prepareExecution(cmd)
+
+	var requestValidator = &util.RequestValidator{}
+	var validatorEntity = requestValidator.
+		AddMethod(method).
+		AddBody(data).
+		AddContentType(contentType).
+		AddRequestCount(count).
+		AddThreads(threads).
+		AddUrl(url).
+		AddTemplate(template).
+		AddTemplateMode(templateMode, seed).
+		AddTemplateFormat(templateType, templateDelimiter, templateHeader).
+		AddTemplateEngine(templateEngine).
+		AddDataSources(dataSources, dataModes).
+		AddRate(rate).
+		AddStages(stages, stageType).
+		AddDuration(duration).
+		AddThinkTime(thinkTime).
+		AddMaxRate(maxRate, maxRateBurst, controlAddress).
+		AddTimeouts(connectTimeout, tlsTimeout, responseHeaderTimeout, maxTime).
+		AddTransport(keepAlive, connectionPool, maxConnectionsPerHost, idleTimeout, newConnectionPerRequest).
+		AddSleep(sleepMs).
+		AddMaxDuration(maxDuration).
+		AddOutput(output, outputFormat).
+		AddThresholds(thresholds).
+		AddHeaders(headers, headersFile).
+		WithAppConfiguration(appConf).
+		Entity()
+
+	validatorEntity.Validate().ProcessErrors()
+
+	var summary = runExecution(appConf.Request.Method, url, report.Run{Command: cmd.Name(), URL: url, Configuration: flagsConfiguration(cmd.Flags())})
+	processThresholds(summary)
+}
+
+// runExecution performs requests with a given HTTP method concurrently according to the execution flags,
+// prints the statistic report and writes requested output files
+func runExecution(method string, url string, run report.Run) *stats.Summary {
+	var errSetupLogs = util.SetupLogs(appConf.Logs)
+	defer util.ShutdownLogs(appConf.Logs)
+	if errSetupLogs != nil && verbose {
+		_, _ = yellowColor.Println(fmt.Sprintf("Unable to setup log file. Reason: %s", errSetupLogs.Error()))
+	}
+
+	var recorder = stats.NewRecorder()
+	var rawWriter *report.RawWriter
+	if rawOutput != "" {
+		var errRawWriter error
+		rawWriter, errRawWriter = report.CreateRawWriter(rawOutput, report.RawFormatForPath(rawOutput))
+		if errRawWriter != nil {
+			_, _ = yellowColor.Println(fmt.Sprintf("Unable to create raw results file '%s'. Reason: %s", rawOutput, errRawWriter.Error()))
+		} else {
+			recorder.AddWriter(rawWriter)
+		}
+	}
+
+	var timeline *report.Timeline
+	if htmlReport != "" {
+		timeline = report.NewTimeline()
+		recorder.AddWriter(timeline)
+	}
+
+	var profile *load.Profile
+	var maxThreads = threads
+	if len(appConf.Load.Stages) > 0 {
+		profile = load.NewProfile(appConf.Load.Stages)
+		if appConf.Load.StageType == load.StageTypeThreads {
+			maxThreads = int(math.Ceil(profile.MaxTarget()))
+		}
+	}
+
+	var templateSources *util.TemplateSources
+	if appConf.Template.Enabled {
+		var errTemplateSources error
+		templateSources, errTemplateSources = util.OpenTemplateSources(appConf.Template, maxThreads)
+		if errTemplateSources != nil {
+			_, _ = redColor.Println(errTemplateSources.Error())
+			util.ShutdownLogs(appConf.Logs)
+			os.Exit(1)
+		}
+		defer templateSources.Close()
+
+		if verbose {
+			templateSources.Each(printTemplateSource)
+		}
+	}
+
+	if verbose {
+		fmt.Printf("Seed of random values: %d\n", appConf.Template.Seed)
+	}
+
+	httpClients = util.NewHTTPClients(maxThreads, appConf.Request.Timeouts, appConf.Request.Transport)
+	var renderer = util.NewRequestRenderer(url, appConf.Request)
+	var progressWrapper *ui.ProgressWrapper
+	var dispatcher *load.Dispatcher
+
+	if verbose && profile != nil {
+		var descriptions = make([]string, 0, len(profile.Stages()))
+		for _, stage := range profile.Stages() {
+			descriptions = append(descriptions, stage.String())
+		}
+		fmt.Printf("Running %d stage(s) of '%s' targets for %s: %s\n", len(descriptions), appConf.Load.StageType, profile.Duration(), strings.Join(descriptions, ", "))
+	}
+
+	var deadline = executionDeadline(time.Now())
+	var ctx, cancel = newExecutionContext(deadline)
+	if !deadline.IsZero() {
+		util.InfoLog(fmt.Sprintf("Determined execution deadline: %s", deadline.Format(time.RFC3339Nano)), appConf.Logs)
+	}
+	defer cancel()
+
+	// stop is done once no new requests should be sent while ctx is done once in-flight requests should be cancelled
+	var stop, stopScheduling = context.WithCancel(ctx)
+	defer stopScheduling()
+
+	var interruption = load.HandleSignals(gracePeriod, stopScheduling, cancel, onInterrupt, onTerminate)
+	defer interruption.Release()
+
+	rateLimiter = nil
+	if appConf.Load.StageType == load.StageTypeMaxRate && profile != nil {
+		rateLimiter = load.NewLimiter(0, appConf.Load.MaxRateBurst)
+		go rateLimiter.Follow(stop, profile)
+	} else if appConf.Load.MaxRate > 0 {
+		rateLimiter = load.NewLimiter(appConf.Load.MaxRate, appConf.Load.MaxRateBurst)
+		if verbose {
+			fmt.Printf("Limiting requests to a maximum rate of %s with a burst of %d\n", load.FormatRate(appConf.Load.MaxRate), appConf.Load.MaxRateBurst)
+		}
+	}
+
+	if appConf.Load.ControlAddress != "" && rateLimiter != nil {
+		if controlServer := startControlServer(appConf.Load.ControlAddress, rateLimiter); controlServer != nil {
+			defer controlServer.Close()
+		}
+	}
+
+	recorder.Start()
+	if profile != nil && appConf.Load.StageType == load.StageTypeRate {
+		dispatcher = load.NewDispatcher(profile, deadline)
+		progressWrapper = ui.InitTimedProgress(1, profile.Duration(), describeStage(profile))
+		go RateStart(ctx, stop, dispatcher, renderer, progressWrapper, recorder, templateSources)
+	} else if profile != nil && appConf.Load.StageType == load.StageTypeThreads {
+		progressWrapper = ui.InitTimedProgress(1, profile.Duration(), describeStage(profile))
+		go StagesStart(ctx, stop, load.NewWorkers(profile), maxThreads, renderer, progressWrapper, recorder, templateSources)
+	} else if appConf.Load.Rate > 0 && appConf.Load.Duration > 0 {
+		if verbose {
+			fmt.Printf("Sending requests at a constant rate of %s with %d worker(s) for %s\n", load.FormatRate(appConf.Load.Rate), threads, appConf.Load.Duration)
+		}
+
+		dispatcher = load.NewDispatcher(load.ConstantRate{Rate: appConf.Load.Rate}, deadline)
+		progressWrapper = ui.InitTimedProgress(1, appConf.Load.Duration, func(int) string { return "Scheduled" })
+		go RateStart(ctx, stop, dispatcher, renderer, progressWrapper, recorder, templateSources)
+	} else if appConf.Load.Rate > 0 {
+		if verbose {
+			fmt.Printf("Sending %d request(s) at a constant rate of %s with %d worker(s)\n", count, load.FormatRate(appConf.Load.Rate), threads)
+		}
+
+		dispatcher = load.NewDispatcher(load.ConstantRate{Rate: appConf.Load.Rate, Total: count}, deadline)
+		progressWrapper = ui.InitRateProgress(count)
+		go RateStart(ctx, stop, dispatcher, renderer, progressWrapper, recorder, templateSources)
+	} else {
+		if profile != nil {
+			progressWrapper = ui.InitTimedProgress(threads, profile.Duration(), describeStage(profile))
+		} else if appConf.Load.Duration > 0 {
+			progressWrapper = ui.InitTimedProgress(threads, appConf.Load.Duration, func(threadID int) string { return fmt.Sprintf("Thread #%-4d", threadID) })
+		} else {
+			progressWrapper = ui.InitMultiProgress(threads, count)
+		}
+
+		for i := 0; i < threads; i++ {
+			go ThreadStart(ctx, stop, i, renderer, progressWrapper, recorder, templateSources)
+		}
+	}
+
+	progressWrapper.WaitForCompletion()
+	recorder.Stop()
+
+	var summary = recorder.Summary()
+	if dispatcher != nil {
+		summary.LateDispatches = dispatcher.Late()
+	}
+	summary.Interrupted = interruption.Interrupted()
+	ui.PrintSummary(summary)
+	if summary.Interrupted {
+		_, _ = yellowColor.Println("Execution was interrupted, the summary covers completed requests only")
+	}
+
+	if rawWriter != nil {
+		var errRawOutput = recorder.WriteErr()
+		if errClose := rawWriter.Close(); errRawOutput == nil {
+			errRawOutput = errClose
+		}
+
+		if errRawOutput != nil {
+			_, _ = yellowColor.Println(fmt.Sprintf("Unable to write raw results file '%s'. Reason: %s", rawOutput, errRawOutput.Error()))
+		}
+	}
+
+	if output != "" {
+		if errOutput := writeSummaryFile(output, outputFormat, run, summary); errOutput != nil {
+			_, _ = yellowColor.Println(fmt.Sprintf("Unable to write summary file '%s'. Reason: %s", output, errOutput.Error()))
+		}
+	}
+
+	if timeline != nil {
+		if errReport := report.WriteHTMLFile(htmlReport, run, summary, timeline); errReport != nil {
+			_, _ = yellowColor.Println(fmt.Sprintf("Unable to write HTML report '%s'. Reason: %s", htmlReport, errReport.Error()))
+		}
+	}
+
+	return summary
+}
+
+// printTemplateSource prints how a template file of a data source with a given name was loaded, how long it took and its memory footprint
+func printTemplateSource(name string, templateSource *util.TemplateSource) {
+	var mode = "loaded into memory"
+	if !templateSource.InMemory() {
+		mode = "indexed by line offsets"
+	}
+
+	var description = "Template file"
+	if name != "" {
+		description = fmt.Sprintf("Data source '%s'", name)
+	}
+
+	fmt.Printf("%s with %d line(s) %s in %s, memory footprint: %d bytes\n", description, templateSource.Size(), mode,
+		templateSource.IndexDuration().Round(time.Microsecond), templateSource.Footprint())
+}
+
+// onInterrupt reports the first received signal, execution stops sending new requests after it
+func onInterrupt(received os.Signal) {
+	util.WarnLog(fmt.Sprintf("Received signal '%s'. Stopping execution", received), appConf.Logs)
+	_, _ = yellowColor.Println(fmt.Sprintf("\nReceived signal '%s'. Waiting up to %s for in-flight requests, send it again to exit immediately", received, gracePeriod))
+}
+
+// onTerminate terminates the application immediately on the second received signal
+func onTerminate(received os.Signal) {
+	util.WarnLog(fmt.Sprintf("Received signal '%s' again. Terminating execution immediately", received), appConf.Logs)
+	util.ShutdownLogs(appConf.Logs)
+	os.Exit(ExitCodeInterrupted)
+}
+
+// startControlServer starts serving a control endpoint of a given limiter on a given address in background.
+// It returns nil if the address can't be listened on
+func startControlServer(address string, limiter *load.Limiter) *http.Server {
+	var listener, errListen = net.Listen("tcp", address)
+	if errListen != nil {
+		_, _ = yellowColor.Println(fmt.Sprintf("Unable to start control endpoint on '%s'. Reason: %s", address, errListen.Error()))
+		return nil
+	}
+
+	var server = &http.Server{Handler: load.NewControlHandler(limiter)}
+	go func() {
+		if errServe := server.Serve(listener); errServe != nil && errServe != http.ErrServerClosed {
+			util.ErrorLog(fmt.Sprintf("Control endpoint has stopped. Reason: %s", errServe.Error()), appConf.Logs)
+		}
+	}()
+
+	if verbose {
+		fmt.Printf("Control endpoint is listening on http://%s%s\n", listener.Addr(), load.ControlPath)
+	}
+	return server
+}
+
+// timedDuration returns a duration of execution which is limited by time rather than by a number of requests:
+// a duration of 'max-rate' stages, 'duration' flag or zero when threads send 'count' requests
+func timedDuration() time.Duration {
+	if len(appConf.Load.Stages) > 0 && appConf.Load.StageType == load.StageTypeMaxRate {
+		return load.NewProfile(appConf.Load.Stages).Duration()
+	}
+	return appConf.Load.Duration
+}
+
+// executionDeadline returns a time by which execution started at a given time should be over according to 'duration'
+// (or 'max-rate' stages) and 'duration-max' flags, the earliest of both wins. It returns zero time when execution is not limited by time
+func executionDeadline(startTime time.Time) time.Time {
+	var deadline time.Time
+	if duration := timedDuration(); duration > 0 {
+		deadline = startTime.Add(duration)
+	}
+
+	if maxDuration > 0 {
+		var maxDeadline = startTime.Add(time.Second * time.Duration(maxDuration))
+		if deadline.IsZero() || maxDeadline.Before(deadline) {
+			deadline = maxDeadline
+		}
+	}
+
+	return deadline
+}
+
+// newExecutionContext creates a context of an execution which is done at a given deadline or when it's cancelled if the deadline is zero
+func newExecutionContext(deadline time.Time) (context.Context, context.CancelFunc) {
+	if deadline.IsZero() {
+		return context.WithCancel(context.Background())
+	}
+
+	return context.WithDeadline(context.Background(), deadline)
+}
+
+// describeStage returns a function which describes a current stage of a given profile and its interpolated target
+// by time elapsed since now
+func describeStage(profile *load.Profile) func(int) string {
+	var startTime = time.Now()
+	return func(int) string {
+		var target, stage, _ = profile.TargetAt(time.Since(startTime))
+		switch appConf.Load.StageType {
+		case load.StageTypeRate:
+			return fmt.Sprintf("Stage %d/%d %s", stage, len(profile.Stages()), load.FormatRate(math.Round(target*10)/10))
+		case load.StageTypeMaxRate:
+			return fmt.Sprintf("Stage %d/%d max %s", stage, len(profile.Stages()), load.FormatRate(math.Round(target*10)/10))
+		}
+		return fmt.Sprintf("Stage %d/%d %d thread(s)", stage, len(profile.Stages()), int(math.Round(target)))
+	}
+}
+
+// processThresholds evaluates configured thresholds against a given summary, prints their outcomes and terminates
+// the application with ExitCodeInterrupted if execution was interrupted or with ExitCodeThresholdsFailed if any of them failed
+func processThresholds(summary *stats.Summary) {
+	var results = make([]stats.ThresholdResult, 0, len(appConf.Thresholds))
+	var passed = true
+	for _, threshold := range appConf.Thresholds {
+		var result = threshold.Evaluate(summary)
+		passed = passed && result.Passed
+		results = append(results, result)
+	}
+
+	ui.PrintThresholds(results)
+	if summary.Interrupted {
+		os.Exit(ExitCodeInterrupted)
+	}
+	if !passed {
+		os.Exit(ExitCodeThresholdsFailed)
+	}
+}
+
+// writeSummaryFile writes a run summary into a file with a given path and format
+func writeSummaryFile(path string, format string, run report.Run, summary *stats.Summary) error {
+	var file, errCreate = os.Create(path)
+	if errCreate != nil {
+		return errCreate
+	}
+
+	var errWrite = report.WriteSummary(file, format, run, summary)
+	if errClose := file.Close(); errWrite == nil {
+		errWrite = errClose
+	}
+
+	return errWrite
+}
+
+// flagsConfiguration collects values of all flags of a command, including default ones, to describe a run configuration.
+// Header values and a request body are redacted since reports of a run are meant to be shared
+func flagsConfiguration(flags *pflag.FlagSet) map[string]string {
+	var configuration = make(map[string]string)
+	flags.VisitAll(func(flag *pflag.Flag) {
+		switch flag.Name {
+		case "help":
+		case "header":
+			var redacted = make([]string, 0, len(headers))
+			for _, header := range headers {
+				redacted = append(redacted, util.RedactHeader(header))
+			}
+			configuration[flag.Name] = "[" + strings.Join(redacted, ",") + "]"
+		case "data":
+			configuration[flag.Name] = util.RedactBody(flag.Value.String())
+		default:
+			configuration[flag.Name] = flag.Value.String()
+		}
+	})
+
+	return configuration
+}
+
+// ThreadStart performs requests of a single thread back-to-back until 'count' requests are sent or, when 'duration'
+// or 'max-rate' stages are set, until a given stop context is done. No new requests are sent once the stop context
+// is done and in-flight requests are cancelled once a given ctx is done
+func ThreadStart(ctx context.Context, stop context.Context, threadID int, renderer util.RequestRenderer, progressWrapper *ui.ProgressWrapper, recorder *stats.Recorder, templateSources *util.TemplateSources) {
+	progressWrapper.AddBar(threadID)
+	defer progressWrapper.DoneExecution()
+
+	var threadRenderer = renderer.ForThread()
+	var random = newThreadRandom(threadID)
+
+	var timed = timedDuration() > 0
+	for i := 0; timed || i < count; i++ {
+		if stop.Err() != nil {
+			util.WarnLog(fmt.Sprintf("Execution is stopped. Terminating execution of thread with id: %d", threadID), appConf.Logs)
+			break
+		}
+
+		var requestStartTime = time.Now()
+		var templateRecord, sent, hasLine = performRequest(ctx, stop, threadID, i, 0, threadRenderer, recorder, templateSources, time.Time{})
+		if !hasLine {
+			break
+		}
+
+		if sent {
+			progressWrapper.Increment(threadID, time.Since(requestStartTime))
+			think(stop, threadID, random, templateRecord, recorder)
+		}
+	}
+
+	// skipped iterations don't advance the bar, so it's completed explicitly
+	progressWrapper.CompleteProgress(threadID)
+}
+
+// newThreadRandom creates a source of random think times of a thread with a given id derived from the seed of a run
+func newThreadRandom(threadID int) *rand.Rand {
+	return rand.New(rand.NewSource(appConf.Template.Seed + int64(threadID)))
+}
+
+// think pauses a thread with a given id for a think time of the configured distribution after a request which used
+// a given template record and records the effective pause. The pause is cut short once a given context is done
+func think(ctx context.Context, threadID int, random *rand.Rand, templateRecord util.TemplateRecord, recorder *stats.Recorder) {
+	var thinkTime = appConf.Load.ThinkTime
+	if thinkTime == nil {
+		return
+	}
+
+	var duration = thinkTime.Next(random)
+	if thinkTime.Distribution == load.ThinkTimeTemplate {
+		var value, found = "", false
+		if templateRecord != nil {
+			value, found = templateRecord.Value(thinkTime.Key)
+		}
+		if !found {
+			util.ErrorLog(fmt.Sprintf("Can not find think time value for placeholder key '%s' in template lines %s. Skipping the pause", thinkTime.Key, templateRecord), appConf.Logs)
+			return
+		}
+
+		var errParse error
+		if duration, errParse = load.ParseThinkTimeValue(value); errParse != nil {
+			util.ErrorLog(fmt.Sprintf("Can not parse think time value. Reason: %s. Skipping the pause", errParse.Error()), appConf.Logs)
+			return
+		}
+	}
+
+	util.InfoLog(fmt.Sprintf("Sleeping thread with id: %d for %s before the next itteration", threadID, duration), appConf.Logs)
+	var startTime = time.Now()
+	sleepContext(ctx, duration)
+	recorder.RecordThinkTime(time.Since(startTime))
+	util.InfoLog(fmt.Sprintf("Resumed thread with id: %d after sleeping for %s", threadID, duration), appConf.Logs)
+}
+
+// sleepContext pauses the current goroutine for a given duration or until a given context is done
+func sleepContext(ctx context.Context, duration time.Duration) {
+	var timer = time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
+
+// RateStart performs requests scheduled by a given dispatcher with a pool of 'threads' workers
+// and reports their progress to a single progress bar. Dispatching is stopped once a given stop context is done
+func RateStart(ctx context.Context, stop context.Context, dispatcher *load.Dispatcher, renderer util.RequestRenderer, progressWrapper *ui.ProgressWrapper, recorder *stats.Recorder, templateSources *util.TemplateSources) {
+	progressWrapper.AddBar(0)
+	defer progressWrapper.DoneExecution()
+
+	go func() {
+		<-stop.Done()
+		dispatcher.Stop()
+	}()
+
+	var waitGroup = &sync.WaitGroup{}
+	waitGroup.Add(threads)
+	for i := 0; i < threads; i++ {
+		go func(threadID int) {
+			defer waitGroup.Done()
+
+			var threadRenderer = renderer.ForThread()
+			for job := range dispatcher.Jobs() {
+				var requestStartTime = time.Now()
+				var _, sent, hasLine = performRequest(ctx, stop, threadID, job.Iteration, job.Stage, threadRenderer, recorder, templateSources, job.ScheduledTime)
+				if !hasLine {
+					dispatcher.Stop()
+					continue
+				}
+
+				if sent {
+					progressWrapper.Increment(0, time.Since(requestStartTime))
+				}
+			}
+		}(i)
+	}
+
+	dispatcher.Run()
+	waitGroup.Wait()
+	progressWrapper.CompleteProgress(0)
+}
+
+// StagesStart runs given workers following a load profile of concurrent threads until the profile is over,
+// a given stop context is done or template lines are exhausted. It reports elapsed time to a single progress bar
+func StagesStart(ctx context.Context, stop context.Context, workers *load.Workers, maxWorkers int, renderer util.RequestRenderer, progressWrapper *ui.ProgressWrapper, recorder *stats.Recorder, templateSources *util.TemplateSources) {
+	progressWrapper.AddBar(0)
+	defer progressWrapper.DoneExecution()
+
+	go func() {
+		<-stop.Done()
+		workers.Stop()
+	}()
+
+	var threadRenderers = make([]util.RequestRenderer, maxWorkers)
+	var threadRandoms = make([]*rand.Rand, maxWorkers)
+	for i := range threadRenderers {
+		threadRenderers[i] = renderer.ForThread()
+		threadRandoms[i] = newThreadRandom(i)
+	}
+
+	workers.Run(func(threadID int, iteration int, stage int) bool {
+		var templateRecord, sent, hasLine = performRequest(ctx, stop, threadID, iteration, stage, threadRenderers[threadID], recorder, templateSources, time.Time{})
+		if sent {
+			think(stop, threadID, threadRandoms[threadID], templateRecord, recorder)
+		}
+		return hasLine
+	})
+
+	progressWrapper.CompleteProgress(0)
+}
+
+// performRequest renders and sends a single request of a given thread and records its result. Latency is measured
+// from a given scheduled time or from the actual send time when it's zero. A given stage of a load profile is recorded
+// along with the result. Requests are cancelled once a given ctx is done, such requests are not recorded as
+// they are cut off by the end of execution rather than failed. A request is skipped when a given stop context is done
+// before it's rendered. When 'max-rate' is set, a request waits for a token of the shared rate limiter before it's rendered.
+// It returns a template record used by the request, whether the request was actually sent and false when
+// template lines are exhausted
+func performRequest(ctx context.Context, stop context.Context, threadID int, iteration int, stage int, renderer util.RequestRenderer, recorder *stats.Recorder, templateSources *util.TemplateSources, scheduledTime time.Time) (util.TemplateRecord, bool, bool) {
+	// a token and the stop check come first, so a skipped request doesn't consume a template line
+	if rateLimiter != nil {
+		if errWait := rateLimiter.Wait(stop); errWait != nil {
+			return nil, false, true
+		}
+
+		if stage == 0 {
+			stage = rateLimiter.Stage()
+		}
+	}
+
+	if stop.Err() != nil {
+		return nil, false, true
+	}
+
+	var method = appConf.Request.Method
+	var sequence = atomic.AddInt64(&requestSequence, 1) - 1
+	var functionContext = &util.FunctionContext{
+		ThreadID:  threadID,
+		Iteration: iteration,
+		Sequence:  sequence,
+		Faker:     faker.NewForSequence(appConf.Template.Seed, sequence),
+	}
+
+	var templateRecord util.TemplateRecord
+	if templateSources != nil {
+		var record, hasLine, errReadLine = templateSources.Next(threadID)
+		if !hasLine {
+			util.WarnLog(fmt.Sprintf("Template lines are exhausted. Terminating execution of thread with id: %d", threadID), appConf.Logs)
+			return nil, false, false
+		}
+
+		if errReadLine != nil {
+			util.ErrorLog(fmt.Sprintf("Can not read template lines. Reason: %s. Skipping this iteration", errReadLine.Error()), appConf.Logs)
+			return nil, false, true
+		}
+
+		templateRecord = record
+		util.InfoLog(fmt.Sprintf("Received template lines %s", templateRecord), appConf.Logs)
+	}
+
+	var rendered, errRender = renderer.Render(templateRecord, functionContext)
+	if errRender != nil {
+		util.ErrorLog(fmt.Sprintf("Can not render %s request. Reason: %s. Skipping this iteration", method, errRender.Error()), appConf.Logs)
+		return templateRecord, false, true
+	}
+
+	var requestUrl = rendered.URL
+	var request, errNewRequest = newRequest(ctx, method, rendered)
+	if errNewRequest != nil {
+		util.ErrorLog(fmt.Sprintf("Can not create %s request for address: '%s'. Reason: %s. Skipping this iteration", method, requestUrl, errNewRequest.Error()), appConf.Logs)
+		return templateRecord, false, true
+	}
+
+	var sendTime = time.Now()
+	if scheduledTime.IsZero() {
+		scheduledTime = sendTime
+	}
+
+	var result, completed = util.SendRequest(ctx, httpClients[threadID], request, appConf.Request.Timeouts.Total)
+	if !completed {
+		util.WarnLog(fmt.Sprintf("HTTP %s request to address: '%s' was cancelled at the end of execution", method, requestUrl), appConf.Logs)
+		return templateRecord, true, true
+	}
+
+	result.ThreadID = threadID
+	result.StartTime = scheduledTime
+	result.URL = requestUrl
+	result.Latency = time.Since(scheduledTime)
+	result.Stage = stage
+
+	if result.Err != nil {
+		util.ErrorLog(fmt.Sprintf("Received an error on HTTP %s request from address: '%s' with message: %s", method, requestUrl, result.Err.Error()), appConf.Logs)
+	} else {
+		util.WarnLog(fmt.Sprintf("Received HTTP %s response with status code: %d from address '%s' with %d bytes", method, result.StatusCode, requestUrl, result.Bytes), appConf.Logs)
+	}
+
+	recorder.Record(result)
+
+	return templateRecord, true, true
+}
+
+// newRequest creates an HTTP request with a given method and rendered URL, headers and body.
+// A content type of the body is taken from the request configuration, the request is cancelled once a given context is done
+func newRequest(ctx context.Context, method string, rendered *util.RenderedRequest) (*http.Request, error) {
+	var body io.Reader
+	if rendered.Body != nil {
+		body = bytes.NewReader(rendered.Body)
+	}
+
+	var request, errNewRequest = http.NewRequestWithContext(ctx, method, rendered.URL, body)
+	if errNewRequest != nil {
+		return nil, errNewRequest
+	}
+
+	if appConf.Request.ContentType != "" {
+		request.Header.Set("Content-Type", appConf.Request.ContentType)
+	}
+
+	for _, header := range rendered.Headers {
+		if strings.EqualFold(header.Name, "Host") {
+			request.Host = header.Value
+		} else {
+			request.Header.Add(header.Name, header.Value)
+		}
+	}
+
+	return request, nil
+}
@@ -13,39 +13,26 @@ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
 See the License for the specific language governing permissions and
 limitations under the License.
 */
+
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
+	"net/http"
 )
 
-// putCmd represents the put command
 var putCmd = &cobra.Command{
-	Use:   "put",
-	Short: "A brief description of your command",
-	Long: `A longer description that spans multiple lines and likely contains examples
-and usage of using your command. For example:
-
-Cobra is a CLI library for Go that empowers applications.
-This application is a tool to generate the needed files
-to quickly create a Cobra application.`,
+	Use:   "put <URL> [flags]",
+	Short: "Performs HTTP PUT request(s) with an optional body based on specified options",
+	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("put called")
+		validateAndExecute(cmd, http.MethodPut, args[0])
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(putCmd)
 
-	// Here you will define your flags and configuration settings.
-
-	// Cobra supports Persistent Flags which will work for this command
-	// and all subcommands, e.g.:
-	// putCmd.PersistentFlags().String("foo", "", "A help for foo")
-
-	// Cobra supports local flags which will only run when this command
-	// is called directly, e.g.:
-	// putCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+	addExecutionFlags(putCmd, http.MethodPut)
+	addBodyFlags(putCmd)
 }
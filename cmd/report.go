@@ -0,0 +1,87 @@
+/*
+Copyright © 2020 Vlad Krava <vkrava4@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/vkrava4/curlson/report"
+	"github.com/vkrava4/curlson/stats"
+	"github.com/vkrava4/curlson/ui"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+var reportOutput string
+
+var redColor = color.New(color.FgRed)
+
+var reportCmd = &cobra.Command{
+	Use:   "report <FILE> [flags]",
+	Short: "Generates an HTML report from a raw results file previously written with 'raw-output' flag",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var rawResults = args[0]
+		if reportOutput == "" {
+			reportOutput = strings.TrimSuffix(rawResults, filepath.Ext(rawResults)) + ".html"
+		}
+
+		var recorder = stats.NewRecorder()
+		var timeline = report.NewTimeline()
+		recorder.AddWriter(timeline)
+
+		var startTime, endTime time.Time
+		var errRead = report.ReadRawResults(rawResults, func(result stats.Result) {
+			if startTime.IsZero() || result.StartTime.Before(startTime) {
+				startTime = result.StartTime
+			}
+			if resultEndTime := result.StartTime.Add(result.Latency); resultEndTime.After(endTime) {
+				endTime = resultEndTime
+			}
+
+			recorder.Record(result)
+		})
+
+		if errRead != nil {
+			_, _ = redColor.Println(fmt.Sprintf("Unable to read raw results file '%s'. Reason: %s", rawResults, errRead.Error()))
+			os.Exit(1)
+		}
+
+		recorder.StartAt(startTime)
+		recorder.StopAt(endTime)
+
+		var summary = recorder.Summary()
+		ui.PrintSummary(summary)
+
+		var run = report.Run{Command: cmd.Name(), URL: rawResults, Configuration: map[string]string{"raw-results": rawResults}}
+		if errReport := report.WriteHTMLFile(reportOutput, run, summary, timeline); errReport != nil {
+			_, _ = redColor.Println(fmt.Sprintf("Unable to write HTML report '%s'. Reason: %s", reportOutput, errReport.Error()))
+			os.Exit(1)
+		}
+
+		fmt.Println(fmt.Sprintf("HTML report has been written to '%s'", reportOutput))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+
+	reportCmd.Flags().StringVarP(&reportOutput, "output", "o", "", "A file path of the generated HTML report. By default it's a raw results file path with '.html' extension")
+}
@@ -0,0 +1,56 @@
+/*
+Copyright © 2020 Vlad Krava <vkrava4@gmail.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"net/http"
+)
+
+var method string
+
+var requestCmd = &cobra.Command{
+	Use:   "request <URL> [flags]",
+	Short: "Performs HTTP request(s) with any method based on specified options",
+	Long: `Performs HTTP request(s) with any method based on specified options.
+
+Standard methods GET, POST, PUT, PATCH, DELETE, HEAD and OPTIONS as well as custom ones are supported.
+Like in curl, POST is used by default when a request body is given with 'data' flag, otherwise GET is used.
+The 'get' and 'put' commands are shortcuts for 'request -X GET' and 'request -X PUT'`,
+	Example: `  curlson request -X DELETE http://localhost:8080/users/1
+  curlson request http://localhost:8080/users -d '{"name":"curlson"}' --content-type application/json`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var requestMethod = method
+		if !cmd.Flags().Changed("request") {
+			requestMethod = http.MethodGet
+			if data != "" {
+				requestMethod = http.MethodPost
+			}
+		}
+
+		validateAndExecute(cmd, requestMethod, args[0])
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(requestCmd)
+
+	requestCmd.Flags().StringVarP(&method, "request", "X", http.MethodGet, "An HTTP method of requests, e.g. GET, POST, PUT, PATCH, DELETE, HEAD, OPTIONS or any custom one")
+	addExecutionFlags(requestCmd, "HTTP")
+	addBodyFlags(requestCmd)
+}
@@ -0,0 +1,66 @@
+package faker
+
+var firstNames = []string{
+	"James", "Mary", "John", "Patricia", "Robert", "Jennifer", "Michael", "Linda", "William", "Elizabeth",
+	"David", "Barbara", "Richard", "Susan", "Joseph", "Jessica", "Thomas", "Sarah", "Charles", "Karen",
+	"Olivia", "Liam", "Emma", "Noah", "Ava", "Lucas", "Sofia", "Mateo", "Mia", "Leo",
+	"Anna", "Ivan", "Olena", "Taras", "Marta", "Pierre", "Claire", "Hans", "Greta", "Yuki",
+}
+
+var lastNames = []string{
+	"Smith", "Johnson", "Williams", "Brown", "Jones", "Garcia", "Miller", "Davis", "Rodriguez", "Martinez",
+	"Hernandez", "Lopez", "Gonzalez", "Wilson", "Anderson", "Thomas", "Taylor", "Moore", "Jackson", "Martin",
+	"Lee", "Perez", "Thompson", "White", "Harris", "Clark", "Lewis", "Walker", "Young", "King",
+	"Kovalenko", "Shevchenko", "Dubois", "Muller", "Schmidt", "Rossi", "Tanaka", "Novak", "Silva", "Jensen",
+}
+
+var emailDomains = []string{"example.com", "example.net", "example.org"}
+
+var streetNames = []string{
+	"Main", "Oak", "Pine", "Maple", "Cedar", "Elm", "Washington", "Lake", "Hill", "Park",
+	"Sunset", "Lincoln", "Jackson", "River", "Church", "Willow", "Highland", "Forest", "Spring", "Meadow",
+}
+
+var streetSuffixes = []string{"Street", "Avenue", "Road", "Boulevard", "Lane", "Drive", "Court", "Way", "Place", "Terrace"}
+
+var cities = []string{
+	"Springfield", "Riverside", "Franklin", "Greenville", "Bristol", "Clinton", "Fairview", "Salem", "Madison", "Georgetown",
+	"Kyiv", "Lviv", "Berlin", "Munich", "Paris", "Lyon", "Amsterdam", "Rotterdam", "London", "Manchester",
+	"Madrid", "Lisbon", "Rome", "Vienna", "Prague", "Warsaw", "Stockholm", "Oslo", "Tokyo", "Toronto",
+}
+
+var countries = []string{
+	"United States", "Canada", "Ukraine", "Germany", "France", "Netherlands", "United Kingdom", "Spain", "Portugal", "Italy",
+	"Austria", "Czech Republic", "Poland", "Sweden", "Norway", "Japan", "Australia", "Brazil", "Mexico", "Ireland",
+}
+
+// ibanFormats describe BBAN patterns of supported countries: 'a' is an uppercase letter, 'n' is a digit
+var ibanFormats = []struct {
+	country string
+	pattern string
+}{
+	{country: "DE", pattern: "nnnnnnnnnnnnnnnnnn"},
+	{country: "GB", pattern: "aaaannnnnnnnnnnnnn"},
+	{country: "NL", pattern: "aaaannnnnnnnnn"},
+	{country: "FR", pattern: "nnnnnnnnnnnnnnnnnnnnnnn"},
+	{country: "ES", pattern: "nnnnnnnnnnnnnnnnnnnn"},
+	{country: "UA", pattern: "nnnnnnnnnnnnnnnnnnnnnnnnn"},
+}
+
+var userAgents = []string{
+	"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/80.0.3987.132 Safari/537.36",
+	"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_3) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/13.0.5 Safari/605.1.15",
+	"Mozilla/5.0 (X11; Linux x86_64; rv:73.0) Gecko/20100101 Firefox/73.0",
+	"Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:73.0) Gecko/20100101 Firefox/73.0",
+	"Mozilla/5.0 (iPhone; CPU iPhone OS 13_3_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/13.0.5 Mobile/15E148 Safari/604.1",
+	"Mozilla/5.0 (Linux; Android 10; SM-G973F) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/80.0.3987.119 Mobile Safari/537.36",
+	"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/80.0.3987.132 Safari/537.36 Edg/80.0.361.66",
+}
+
+var loremWords = []string{
+	"lorem", "ipsum", "dolor", "sit", "amet", "consectetur", "adipiscing", "elit", "sed", "do",
+	"eiusmod", "tempor", "incididunt", "ut", "labore", "et", "dolore", "magna", "aliqua", "enim",
+	"ad", "minim", "veniam", "quis", "nostrud", "exercitation", "ullamco", "laboris", "nisi", "aliquip",
+	"ex", "ea", "commodo", "consequat", "duis", "aute", "irure", "in", "reprehenderit", "voluptate",
+	"velit", "esse", "cillum", "fugiat", "nulla", "pariatur", "excepteur", "sint", "occaecat", "cupidatat",
+}
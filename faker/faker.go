@@ -0,0 +1,207 @@
+package faker
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DateLayout is a layout of dates accepted and generated by Faker
+const DateLayout = "2006-01-02"
+
+var alphanumeric = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// Faker generates realistic synthetic data. Its output is fully determined by a seed and it never uses network.
+// Faker is not safe for a concurrent use: every goroutine should use its own instance
+type Faker struct {
+	random *rand.Rand
+}
+
+// New creates a Faker with a given seed
+func New(seed int64) *Faker {
+	return &Faker{random: rand.New(newSplitMix64(uint64(seed)))}
+}
+
+// NewForSequence creates a Faker for a given sequence number of a run with a given seed. Fakers of different
+// sequence numbers are independent, so values generated for a request don't depend on an order in which threads run
+func NewForSequence(seed int64, sequence int64) *Faker {
+	return &Faker{random: rand.New(newSplitMix64(uint64(seed) ^ mix64(uint64(sequence)+1)))}
+}
+
+// Int returns a random integer in range [min, max]. It panics if min is greater than max
+func (f *Faker) Int(min int64, max int64) int64 {
+	if min > max {
+		panic(fmt.Sprintf("faker: invalid range [%d, %d]", min, max))
+	}
+
+	var span = uint64(max - min)
+	if span == ^uint64(0) {
+		return int64(f.random.Uint64())
+	}
+	return min + int64(f.uint64n(span+1))
+}
+
+// String returns a random alphanumeric string of a given length
+func (f *Faker) String(length int) string {
+	var value = make([]byte, length)
+	for i := range value {
+		value[i] = alphanumeric[f.random.Intn(len(alphanumeric))]
+	}
+	return string(value)
+}
+
+// UUID returns a random UUID (version 4) according to RFC 4122
+func (f *Faker) UUID() string {
+	var uuid = make([]byte, 16)
+	_, _ = f.random.Read(uuid)
+
+	uuid[6] = (uuid[6] & 0x0f) | 0x40
+	uuid[8] = (uuid[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:])
+}
+
+// FirstName returns a random first name
+func (f *Faker) FirstName() string {
+	return f.pick(firstNames)
+}
+
+// LastName returns a random last name
+func (f *Faker) LastName() string {
+	return f.pick(lastNames)
+}
+
+// Name returns a random full name
+func (f *Faker) Name() string {
+	return f.FirstName() + " " + f.LastName()
+}
+
+// Email returns a random email address at one of domains reserved for documentation, see RFC 2606
+func (f *Faker) Email() string {
+	return fmt.Sprintf("%s.%s%d@%s", strings.ToLower(f.FirstName()), strings.ToLower(f.LastName()), f.Int(1, 999), f.pick(emailDomains))
+}
+
+// Phone returns a random phone number in North American format with a fictional '555' exchange
+func (f *Faker) Phone() string {
+	return fmt.Sprintf("+1-%d-555-%04d", f.Int(201, 989), f.Int(100, 199))
+}
+
+// Street returns a random street address
+func (f *Faker) Street() string {
+	return fmt.Sprintf("%d %s %s", f.Int(1, 9999), f.pick(streetNames), f.pick(streetSuffixes))
+}
+
+// City returns a random city name
+func (f *Faker) City() string {
+	return f.pick(cities)
+}
+
+// Country returns a random country name
+func (f *Faker) Country() string {
+	return f.pick(countries)
+}
+
+// ZipCode returns a random five digit zip code
+func (f *Faker) ZipCode() string {
+	return fmt.Sprintf("%05d", f.Int(501, 99950))
+}
+
+// Address returns a random full address
+func (f *Faker) Address() string {
+	return fmt.Sprintf("%s, %s %s, %s", f.Street(), f.City(), f.ZipCode(), f.Country())
+}
+
+// IBAN returns a random International Bank Account Number with valid check digits
+func (f *Faker) IBAN() string {
+	var format = ibanFormats[f.random.Intn(len(ibanFormats))]
+
+	var bban = make([]byte, len(format.pattern))
+	for i, kind := range format.pattern {
+		if kind == 'a' {
+			bban[i] = byte('A' + f.random.Intn(26))
+		} else {
+			bban[i] = byte('0' + f.random.Intn(10))
+		}
+	}
+
+	return format.country + ibanCheckDigits(format.country, string(bban)) + string(bban)
+}
+
+// IPv4 returns a random IPv4 address
+func (f *Faker) IPv4() string {
+	return fmt.Sprintf("%d.%d.%d.%d", f.Int(1, 223), f.random.Intn(256), f.random.Intn(256), f.Int(1, 254))
+}
+
+// IPv6 returns a random IPv6 address in a full form
+func (f *Faker) IPv6() string {
+	var groups = make([]string, 8)
+	for i := range groups {
+		groups[i] = strconv.FormatInt(int64(f.random.Intn(0x10000)), 16)
+	}
+	return strings.Join(groups, ":")
+}
+
+// UserAgent returns a random user agent of a popular browser
+func (f *Faker) UserAgent() string {
+	return f.pick(userAgents)
+}
+
+// Word returns a random lorem ipsum word
+func (f *Faker) Word() string {
+	return f.pick(loremWords)
+}
+
+// Lorem returns a given number of random lorem ipsum words separated by spaces
+func (f *Faker) Lorem(words int) string {
+	var values = make([]string, words)
+	for i := range values {
+		values[i] = f.Word()
+	}
+	return strings.Join(values, " ")
+}
+
+// Date returns a random date in range [from, to]. Time of day of given dates is ignored
+func (f *Faker) Date(from time.Time, to time.Time) time.Time {
+	var fromDay = from.Unix() / secondsPerDay
+	var toDay = to.Unix() / secondsPerDay
+	if fromDay > toDay {
+		fromDay, toDay = toDay, fromDay
+	}
+
+	return time.Unix(f.Int(fromDay, toDay)*secondsPerDay, 0).UTC()
+}
+
+func (f *Faker) pick(values []string) string {
+	return values[f.random.Intn(len(values))]
+}
+
+// uint64n returns a random number in range [0, n) without a modulo bias
+func (f *Faker) uint64n(n uint64) uint64 {
+	var limit = ^uint64(0) - ^uint64(0)%n
+	for {
+		var value = f.random.Uint64()
+		if value < limit {
+			return value % n
+		}
+	}
+}
+
+const secondsPerDay = 24 * 60 * 60
+
+// ibanCheckDigits calculates check digits of an IBAN according to ISO 13616 (mod 97-10)
+func ibanCheckDigits(country string, bban string) string {
+	var digits strings.Builder
+	for _, c := range bban + country + "00" {
+		if c >= 'A' && c <= 'Z' {
+			digits.WriteString(strconv.Itoa(int(c-'A') + 10))
+		} else {
+			digits.WriteRune(c)
+		}
+	}
+
+	var number, _ = new(big.Int).SetString(digits.String(), 10)
+	var remainder = new(big.Int).Mod(number, big.NewInt(97)).Int64()
+	return fmt.Sprintf("%02d", 98-remainder)
+}
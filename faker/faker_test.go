@@ -0,0 +1,134 @@
+package faker
+
+import (
+	"math/big"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFakerIsDeterministicForSeed(t *testing.T) {
+	var first = New(42)
+	var second = New(42)
+
+	for i := 0; i < 20; i++ {
+		var firstValue = first.Name() + first.Email() + first.IBAN() + first.Address() + first.UUID()
+		var secondValue = second.Name() + second.Email() + second.IBAN() + second.Address() + second.UUID()
+		if firstValue != secondValue {
+			t.Fatalf("Values generated with the same seed should be equal, actual: '%s' and '%s'", firstValue, secondValue)
+		}
+	}
+}
+
+func TestFakerForSequence(t *testing.T) {
+	if NewForSequence(7, 1).UUID() != NewForSequence(7, 1).UUID() {
+		t.Error("Values generated for the same seed and sequence should be equal")
+	}
+
+	if NewForSequence(7, 1).UUID() == NewForSequence(7, 2).UUID() {
+		t.Error("Values generated for different sequences should differ")
+	}
+
+	if NewForSequence(7, 1).UUID() == NewForSequence(8, 1).UUID() {
+		t.Error("Values generated for different seeds should differ")
+	}
+}
+
+func TestFakerInt(t *testing.T) {
+	var faker = New(1)
+	var seen = make(map[int64]bool)
+	for i := 0; i < 1000; i++ {
+		var value = faker.Int(-3, 3)
+		if value < -3 || value > 3 {
+			t.Fatalf("Value %d is out of range", value)
+		}
+		seen[value] = true
+	}
+
+	if len(seen) != 7 {
+		t.Errorf("All values of range should be generated, actual: %v", seen)
+	}
+}
+
+func TestFakerFormats(t *testing.T) {
+	var faker = New(3)
+	var expectations = map[string]func() string{
+		`^[a-z]+\.[a-z]+\d{1,3}@example\.(com|net|org)$`: faker.Email,
+		`^\+1-\d{3}-555-01\d{2}$`:                        faker.Phone,
+		`^\d{5}$`:                                        faker.ZipCode,
+		`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`: faker.UUID,
+		`^[A-Z][a-z]+ [A-Z][a-z]+$`: faker.Name,
+		`^Mozilla/5\.0 `:            faker.UserAgent,
+	}
+
+	for pattern, generate := range expectations {
+		var regex = regexp.MustCompile(pattern)
+		for i := 0; i < 20; i++ {
+			if value := generate(); !regex.MatchString(value) {
+				t.Errorf("Value '%s' doesn't match '%s'", value, pattern)
+			}
+		}
+	}
+}
+
+func TestFakerIPAddresses(t *testing.T) {
+	var faker = New(4)
+	for i := 0; i < 20; i++ {
+		if ip := net.ParseIP(faker.IPv4()); ip == nil || ip.To4() == nil {
+			t.Errorf("Invalid IPv4 address: %v", ip)
+		}
+
+		if ip := net.ParseIP(faker.IPv6()); ip == nil {
+			t.Errorf("Invalid IPv6 address: %v", ip)
+		}
+	}
+}
+
+func TestFakerIBANHasValidCheckDigits(t *testing.T) {
+	var faker = New(5)
+	for i := 0; i < 50; i++ {
+		var iban = faker.IBAN()
+
+		var digits strings.Builder
+		for _, c := range iban[4:] + iban[:4] {
+			if c >= 'A' && c <= 'Z' {
+				digits.WriteString(strconv.Itoa(int(c-'A') + 10))
+			} else {
+				digits.WriteRune(c)
+			}
+		}
+
+		var number, _ = new(big.Int).SetString(digits.String(), 10)
+		if new(big.Int).Mod(number, big.NewInt(97)).Int64() != 1 {
+			t.Errorf("IBAN '%s' has invalid check digits", iban)
+		}
+	}
+}
+
+func TestFakerDate(t *testing.T) {
+	var faker = New(6)
+	var from, _ = time.Parse(DateLayout, "2020-02-01")
+	var to, _ = time.Parse(DateLayout, "2020-02-03")
+
+	var seen = make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		var date = faker.Date(from, to)
+		if date.Before(from) || date.After(to) {
+			t.Fatalf("Date %v is out of range", date)
+		}
+		seen[date.Format(DateLayout)] = true
+	}
+
+	if len(seen) != 3 {
+		t.Errorf("All dates of range should be generated, actual: %v", seen)
+	}
+}
+
+func TestFakerLorem(t *testing.T) {
+	if words := strings.Fields(New(7).Lorem(5)); len(words) != 5 {
+		t.Errorf("Unexpected lorem words: %v", words)
+	}
+}
@@ -0,0 +1,31 @@
+package faker
+
+// splitMix64 is a small and fast pseudo-random generator (SplitMix64) implementing rand.Source64.
+// Unlike the default source it's cheap to create, so a new one can be seeded for every request
+type splitMix64 struct {
+	state uint64
+}
+
+func newSplitMix64(seed uint64) *splitMix64 {
+	return &splitMix64{state: seed}
+}
+
+func (s *splitMix64) Seed(seed int64) {
+	s.state = uint64(seed)
+}
+
+func (s *splitMix64) Uint64() uint64 {
+	s.state += 0x9e3779b97f4a7c15
+	return mix64(s.state)
+}
+
+func (s *splitMix64) Int63() int64 {
+	return int64(s.Uint64() >> 1)
+}
+
+// mix64 is a finalizer of SplitMix64 which scrambles bits of a given value
+func mix64(value uint64) uint64 {
+	value = (value ^ (value >> 30)) * 0xbf58476d1ce4e5b9
+	value = (value ^ (value >> 27)) * 0x94d049bb133111eb
+	return value ^ (value >> 31)
+}
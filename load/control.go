@@ -0,0 +1,48 @@
+package load
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// ControlPath is a path of a control endpoint which reads and changes a rate of a Limiter
+const ControlPath = "/max-rate"
+
+// maxControlBodySize is a maximum size of a request body accepted by a control endpoint
+const maxControlBodySize = 1024
+
+// NewControlHandler creates an HTTP handler of a control endpoint of a given limiter. GET request to ControlPath
+// returns the current rate in a form of 'count/s', PUT or POST request changes it to a rate given in the body
+// in any form accepted by ParseRate, e.g. '300/s'
+func NewControlHandler(limiter *Limiter) http.Handler {
+	var mux = http.NewServeMux()
+	mux.HandleFunc(ControlPath, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = fmt.Fprintln(w, FormatRate(limiter.Rate()))
+
+		case http.MethodPut, http.MethodPost:
+			var body, errRead = ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxControlBodySize))
+			if errRead != nil {
+				http.Error(w, errRead.Error(), http.StatusBadRequest)
+				return
+			}
+
+			var rate, errParse = ParseRate(string(body))
+			if errParse != nil {
+				http.Error(w, errParse.Error(), http.StatusBadRequest)
+				return
+			}
+
+			limiter.SetRate(rate)
+			_, _ = fmt.Fprintln(w, FormatRate(limiter.Rate()))
+
+		default:
+			w.Header().Set("Allow", "GET, PUT, POST")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		}
+	})
+
+	return mux
+}
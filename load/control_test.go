@@ -0,0 +1,42 @@
+package load
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestControlHandler(t *testing.T) {
+	var limiter = NewLimiter(10, 1)
+	var handler = NewControlHandler(limiter)
+
+	var recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, ControlPath, nil))
+	if recorder.Code != http.StatusOK || recorder.Body.String() != "10/s\n" {
+		t.Errorf("Unexpected response %d '%s'", recorder.Code, recorder.Body.String())
+	}
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPut, ControlPath, strings.NewReader("30/m")))
+	if recorder.Code != http.StatusOK || limiter.Rate() != 0.5 {
+		t.Errorf("Unexpected response %d '%s' and rate %f", recorder.Code, recorder.Body.String(), limiter.Rate())
+	}
+}
+
+func TestControlHandlerInvalid(t *testing.T) {
+	var limiter = NewLimiter(10, 1)
+	var handler = NewControlHandler(limiter)
+
+	var recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, ControlPath, strings.NewReader("fast")))
+	if recorder.Code != http.StatusBadRequest || limiter.Rate() != 10 {
+		t.Errorf("Unexpected response %d '%s' and rate %f", recorder.Code, recorder.Body.String(), limiter.Rate())
+	}
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodDelete, ControlPath, nil))
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Unexpected response %d", recorder.Code)
+	}
+}
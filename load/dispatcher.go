@@ -0,0 +1,127 @@
+package load
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// lateTolerance is a delay of dispatching after a scheduled time by which a job is not considered late yet
+const lateTolerance = time.Millisecond
+
+// Job is a request scheduled by a Dispatcher
+type Job struct {
+	// Iteration is a sequence number of the job starting from 0
+	Iteration int
+
+	// Stage is a number of a stage of the job starting from 1 or 0 when a schedule has no stages
+	Stage int
+
+	// ScheduledTime is a time at which the request should be sent according to the timetable.
+	// Latencies are measured from this time, so a delay of a busy worker pool is a part of them
+	ScheduledTime time.Time
+}
+
+// Schedule is a timetable of jobs
+type Schedule interface {
+
+	// Next returns a time offset since the start at which a job with a given iteration is scheduled and a number
+	// of its stage. The returned boolean is false when there are no more jobs
+	Next(iteration int) (time.Duration, int, bool)
+}
+
+// ConstantRate is a Schedule of a total number of jobs with a constant rate in jobs per second.
+// When the total is zero jobs are scheduled until a deadline of a Dispatcher or until it is stopped
+type ConstantRate struct {
+	Rate  float64
+	Total int
+}
+
+// Next returns a time offset of a job with a given iteration, scheduled times are calculated from the start
+// to avoid an accumulation of rounding errors
+func (r ConstantRate) Next(iteration int) (time.Duration, int, bool) {
+	if r.Total > 0 && iteration >= r.Total {
+		return 0, 0, false
+	}
+	return time.Duration(float64(iteration) * float64(time.Second) / r.Rate), 0, true
+}
+
+// Dispatcher schedules jobs on a fixed timetable regardless of how fast they are performed (open model).
+// Jobs are handed over to a bounded pool of workers reading Jobs channel. When no worker is free at a scheduled
+// time the job is dispatched as soon as any worker becomes free and is counted as late if that happens later
+// than lateTolerance after the scheduled time
+type Dispatcher struct {
+	schedule Schedule
+	deadline time.Time
+	jobs     chan Job
+	late     int64
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewDispatcher creates a Dispatcher of jobs of a given schedule.
+// When a given deadline is not zero no jobs are scheduled after it
+func NewDispatcher(schedule Schedule, deadline time.Time) *Dispatcher {
+	return &Dispatcher{schedule: schedule, deadline: deadline, jobs: make(chan Job), stop: make(chan struct{})}
+}
+
+// Jobs returns a channel of scheduled jobs which is closed once all jobs are dispatched
+func (d *Dispatcher) Jobs() <-chan Job {
+	return d.jobs
+}
+
+// Late returns a number of jobs which could not be dispatched at their scheduled time because all workers were busy
+func (d *Dispatcher) Late() int64 {
+	return atomic.LoadInt64(&d.late)
+}
+
+// Stop stops dispatching of jobs, e.g. when template lines are exhausted. It's safe to call Stop multiple times
+func (d *Dispatcher) Stop() {
+	d.stopOnce.Do(func() {
+		close(d.stop)
+	})
+}
+
+// Run dispatches jobs according to the timetable starting from now. It blocks until all jobs are dispatched or Stop is called
+func (d *Dispatcher) Run() {
+	defer close(d.jobs)
+
+	var startTime = time.Now()
+	var timer = time.NewTimer(0)
+	defer timer.Stop()
+	<-timer.C
+
+	for i := 0; ; i++ {
+		var offset, stage, scheduled = d.schedule.Next(i)
+		if !scheduled {
+			return
+		}
+
+		var scheduledTime = startTime.Add(offset)
+		if !d.deadline.IsZero() && scheduledTime.After(d.deadline) {
+			return
+		}
+
+		if wait := time.Until(scheduledTime); wait > 0 {
+			timer.Reset(wait)
+			select {
+			case <-timer.C:
+			case <-d.stop:
+				return
+			}
+		}
+
+		var job = Job{Iteration: i, Stage: stage, ScheduledTime: scheduledTime}
+		select {
+		case d.jobs <- job:
+		case <-d.stop:
+			return
+		}
+
+		// jobs overdue behind a blocked one are handed over at once, but they are late all the same
+		if time.Since(scheduledTime) > lateTolerance {
+			atomic.AddInt64(&d.late, 1)
+		}
+	}
+}
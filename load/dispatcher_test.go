@@ -0,0 +1,124 @@
+package load
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDispatcherSchedulesJobsOnTimetable(t *testing.T) {
+	var dispatcher = NewDispatcher(ConstantRate{Rate: 1000, Total: 20}, time.Time{})
+	go dispatcher.Run()
+
+	var jobs []Job
+	for job := range dispatcher.Jobs() {
+		jobs = append(jobs, job)
+	}
+
+	if len(jobs) != 20 {
+		t.Fatalf("Unexpected number of jobs %d", len(jobs))
+	}
+
+	for i, job := range jobs {
+		if job.Iteration != i {
+			t.Errorf("Unexpected iteration %d of job %d", job.Iteration, i)
+		}
+
+		if i > 0 && job.ScheduledTime.Sub(jobs[i-1].ScheduledTime) != time.Millisecond {
+			t.Errorf("Unexpected interval %s between jobs %d and %d", job.ScheduledTime.Sub(jobs[i-1].ScheduledTime), i-1, i)
+		}
+	}
+}
+
+func TestDispatcherCountsLateJobsOfBusyWorkers(t *testing.T) {
+	var dispatcher = NewDispatcher(ConstantRate{Rate: 1000, Total: 10}, time.Time{})
+	var waitGroup = &sync.WaitGroup{}
+	var performed = 0
+
+	waitGroup.Add(1)
+	go func() {
+		defer waitGroup.Done()
+		for range dispatcher.Jobs() {
+			performed++
+			time.Sleep(5 * time.Millisecond)
+		}
+	}()
+
+	dispatcher.Run()
+	waitGroup.Wait()
+
+	if performed != 10 || dispatcher.Late() < 5 {
+		t.Errorf("Unexpected number of performed %d or late %d jobs", performed, dispatcher.Late())
+	}
+}
+
+func TestDispatcherCountsLateJobsOfDrainedBacklog(t *testing.T) {
+	var dispatcher = NewDispatcher(ConstantRate{Rate: 1000, Total: 20}, time.Time{})
+	var stalled = make(chan struct{})
+	var waitGroup = &sync.WaitGroup{}
+
+	// all workers are stalled until jobs scheduled up to 19ms are overdue, then the backlog drains at once
+	for i := 0; i < 4; i++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			for range dispatcher.Jobs() {
+				<-stalled
+			}
+		}()
+	}
+
+	time.AfterFunc(40*time.Millisecond, func() { close(stalled) })
+	dispatcher.Run()
+	waitGroup.Wait()
+
+	if late := dispatcher.Late(); late < 16 || late > 20 {
+		t.Errorf("Unexpected number of late jobs %d", late)
+	}
+}
+
+func TestDispatcherStopsAtDeadline(t *testing.T) {
+	var dispatcher = NewDispatcher(ConstantRate{Rate: 100, Total: 1000}, time.Now().Add(50*time.Millisecond))
+	go dispatcher.Run()
+
+	var performed = 0
+	for range dispatcher.Jobs() {
+		performed++
+	}
+
+	if performed < 4 || performed > 7 {
+		t.Errorf("Unexpected number of performed jobs %d", performed)
+	}
+}
+
+func TestDispatcherStop(t *testing.T) {
+	var dispatcher = NewDispatcher(ConstantRate{Rate: 1000, Total: 1000}, time.Time{})
+	go dispatcher.Run()
+
+	var performed = 0
+	for range dispatcher.Jobs() {
+		performed++
+		if performed == 3 {
+			dispatcher.Stop()
+			dispatcher.Stop()
+		}
+	}
+
+	if performed < 3 || performed > 4 {
+		t.Errorf("Unexpected number of performed jobs %d", performed)
+	}
+}
+
+func TestDispatcherSchedulesUnlimitedJobsUntilDeadline(t *testing.T) {
+	var dispatcher = NewDispatcher(ConstantRate{Rate: 1000}, time.Now().Add(30*time.Millisecond))
+	go dispatcher.Run()
+
+	var performed = 0
+	for range dispatcher.Jobs() {
+		performed++
+	}
+
+	if performed < 20 || performed > 40 {
+		t.Errorf("Unexpected number of performed jobs %d", performed)
+	}
+}
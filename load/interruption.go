@@ -0,0 +1,89 @@
+package load
+
+import (
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// Interruption handles SIGINT and SIGTERM signals during execution. The first signal stops scheduling of new requests
+// and cancels in-flight requests once a grace period is over, the second signal terminates execution immediately
+type Interruption struct {
+	gracePeriod    time.Duration
+	stopScheduling func()
+	cancelRequests func()
+	onInterrupt    func(received os.Signal)
+	onTerminate    func(received os.Signal)
+
+	signals     chan os.Signal
+	released    chan struct{}
+	done        chan struct{}
+	interrupted int32
+}
+
+// HandleSignals starts handling SIGINT and SIGTERM signals in background. On the first signal a given onInterrupt
+// function is called and new requests are stopped with a given stopScheduling function, in-flight requests are
+// cancelled with a given cancelRequests function once a given grace period is over. On the second signal a given
+// onTerminate function is called which is expected to exit the application
+func HandleSignals(gracePeriod time.Duration, stopScheduling func(), cancelRequests func(), onInterrupt func(received os.Signal), onTerminate func(received os.Signal)) *Interruption {
+	var interruption = newInterruption(gracePeriod, stopScheduling, cancelRequests, onInterrupt, onTerminate)
+	signal.Notify(interruption.signals, os.Interrupt, syscall.SIGTERM)
+	go interruption.run()
+
+	return interruption
+}
+
+func newInterruption(gracePeriod time.Duration, stopScheduling func(), cancelRequests func(), onInterrupt func(received os.Signal), onTerminate func(received os.Signal)) *Interruption {
+	return &Interruption{
+		gracePeriod:    gracePeriod,
+		stopScheduling: stopScheduling,
+		cancelRequests: cancelRequests,
+		onInterrupt:    onInterrupt,
+		onTerminate:    onTerminate,
+		signals:        make(chan os.Signal, 1),
+		released:       make(chan struct{}),
+		done:           make(chan struct{}),
+	}
+}
+
+// Interrupted returns true if execution was interrupted by a signal
+func (i *Interruption) Interrupted() bool {
+	return atomic.LoadInt32(&i.interrupted) == 1
+}
+
+// Release stops handling of signals, so they terminate the application as usual. It waits until a signal which
+// is being handled is over, so none of the functions of the interruption are called after Release returns
+func (i *Interruption) Release() {
+	signal.Stop(i.signals)
+	close(i.released)
+	<-i.done
+}
+
+func (i *Interruption) run() {
+	defer close(i.done)
+
+	select {
+	case received := <-i.signals:
+		atomic.StoreInt32(&i.interrupted, 1)
+		i.onInterrupt(received)
+		i.stopScheduling()
+	case <-i.released:
+		return
+	}
+
+	var timer = time.NewTimer(i.gracePeriod)
+	defer timer.Stop()
+	for {
+		select {
+		case <-timer.C:
+			i.cancelRequests()
+		case received := <-i.signals:
+			i.onTerminate(received)
+			return
+		case <-i.released:
+			return
+		}
+	}
+}
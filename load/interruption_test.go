@@ -0,0 +1,142 @@
+package load
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func givenInterruption(gracePeriod time.Duration) (*Interruption, context.Context, context.Context, chan os.Signal) {
+	var ctx, cancel = context.WithCancel(context.Background())
+	var stop, stopScheduling = context.WithCancel(ctx)
+	var terminated = make(chan os.Signal, 1)
+
+	var interruption = newInterruption(gracePeriod, stopScheduling, cancel, func(os.Signal) {}, func(received os.Signal) {
+		terminated <- received
+	})
+	go interruption.run()
+
+	return interruption, ctx, stop, terminated
+}
+
+func TestInterruptionFirstSignal(t *testing.T) {
+	var interruption, ctx, stop, _ = givenInterruption(time.Hour)
+	defer interruption.Release()
+
+	if interruption.Interrupted() {
+		t.Fatal("Execution should not be interrupted before a signal")
+	}
+
+	interruption.signals <- os.Interrupt
+
+	select {
+	case <-stop.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Scheduling should be stopped after the first signal")
+	}
+
+	if !interruption.Interrupted() || ctx.Err() != nil {
+		t.Errorf("In-flight requests should not be cancelled before the grace period is over, interrupted: %v, error: %v", interruption.Interrupted(), ctx.Err())
+	}
+}
+
+func TestInterruptionGracePeriodCancelsInFlightRequests(t *testing.T) {
+	var server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	var interruption, ctx, _, _ = givenInterruption(50 * time.Millisecond)
+	defer interruption.Release()
+
+	var request, _ = http.NewRequest(http.MethodGet, server.URL, nil)
+	var done = make(chan error, 1)
+	go func() {
+		var response, errDo = http.DefaultClient.Do(request.WithContext(ctx))
+		if errDo == nil {
+			_ = response.Body.Close()
+		}
+		done <- errDo
+	}()
+
+	var startTime = time.Now()
+	interruption.signals <- syscall.SIGTERM
+
+	select {
+	case errDo := <-done:
+		if errDo == nil || ctx.Err() != context.Canceled {
+			t.Errorf("In-flight request should be cancelled, error: %v", errDo)
+		}
+		if elapsed := time.Since(startTime); elapsed < 50*time.Millisecond {
+			t.Errorf("In-flight request should be cancelled after the grace period, elapsed: %s", elapsed)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("In-flight request should be cancelled once the grace period is over")
+	}
+}
+
+func TestInterruptionSecondSignal(t *testing.T) {
+	var interruption, ctx, _, terminated = givenInterruption(time.Hour)
+	defer interruption.Release()
+
+	interruption.signals <- os.Interrupt
+	interruption.signals <- os.Interrupt
+
+	select {
+	case received := <-terminated:
+		if received != os.Interrupt || ctx.Err() != nil {
+			t.Errorf("Unexpected termination by signal '%s', error: %v", received, ctx.Err())
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Execution should be terminated after the second signal")
+	}
+}
+
+func TestInterruptionRelease(t *testing.T) {
+	var interruption, ctx, stop, _ = givenInterruption(time.Hour)
+	interruption.Release()
+
+	select {
+	case interruption.signals <- os.Interrupt:
+	default:
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	if interruption.Interrupted() || stop.Err() != nil || ctx.Err() != nil {
+		t.Error("Signals should not be handled once the interruption is released")
+	}
+}
+
+func TestHandleSignals(t *testing.T) {
+	var stop, stopScheduling = context.WithCancel(context.Background())
+	var interrupted = make(chan os.Signal, 1)
+
+	var interruption = HandleSignals(time.Hour, stopScheduling, func() {}, func(received os.Signal) {
+		interrupted <- received
+	}, func(os.Signal) {})
+	defer interruption.Release()
+
+	var process, _ = os.FindProcess(os.Getpid())
+	if errSignal := process.Signal(syscall.SIGTERM); errSignal != nil {
+		t.Fatalf("Unable to send a signal: %v", errSignal)
+	}
+
+	select {
+	case received := <-interrupted:
+		if received != syscall.SIGTERM {
+			t.Errorf("Unexpected signal '%s'", received)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("The signal should be handled")
+	}
+
+	select {
+	case <-stop.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Scheduling should be stopped after the signal")
+	}
+}
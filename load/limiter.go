@@ -0,0 +1,135 @@
+package load
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// Limiter is a token bucket which limits a rate of requests sent by all workers together. Tokens are added
+// at a rate in requests per second up to a burst, every request takes one token and waits until it's available.
+// The rate can be changed at any time and waiting requests follow a new rate immediately. Limiter is safe for concurrent use
+type Limiter struct {
+	mutex      sync.Mutex
+	rate       float64
+	burst      int
+	tokens     float64
+	lastRefill time.Time
+	stage      int
+
+	// changed is closed and replaced every time the rate is changed to wake up waiting requests
+	changed chan struct{}
+}
+
+// NewLimiter creates a Limiter with a given rate in requests per second and a given burst. The bucket is full initially.
+// A zero rate holds all requests until a positive rate is set
+func NewLimiter(rate float64, burst int) *Limiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &Limiter{rate: rate, burst: burst, tokens: float64(burst), lastRefill: time.Now(), changed: make(chan struct{})}
+}
+
+// Rate returns the current rate in requests per second
+func (l *Limiter) Rate() float64 {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.rate
+}
+
+// Burst returns a maximum number of requests which can be sent at once after a period of inactivity
+func (l *Limiter) Burst() int {
+	return l.burst
+}
+
+// SetRate changes the rate in requests per second. Tokens gathered at the previous rate are kept
+func (l *Limiter) SetRate(rate float64) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if rate == l.rate {
+		return
+	}
+
+	l.refill(time.Now())
+	l.rate = rate
+	close(l.changed)
+	l.changed = make(chan struct{})
+}
+
+// Stage returns a number of a stage of a profile followed by the limiter starting from 1 or 0 when it doesn't follow any profile
+func (l *Limiter) Stage() int {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.stage
+}
+
+// Wait blocks until a token is available and takes it. It returns an error of a given context if it's done earlier
+func (l *Limiter) Wait(ctx context.Context) error {
+	for {
+		l.mutex.Lock()
+		var now = time.Now()
+		l.refill(now)
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mutex.Unlock()
+			return nil
+		}
+
+		var changed = l.changed
+		var wait = time.Duration(math.MaxInt64)
+		if l.rate > 0 {
+			wait = time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+		}
+		l.mutex.Unlock()
+
+		var timer = time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-changed:
+			timer.Stop()
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// Follow changes the rate according to interpolated targets of a given profile until the profile is over
+// or a given context is done. Targets of the profile are rates in requests per second. It blocks, so it's
+// expected to be run in a separate goroutine
+func (l *Limiter) Follow(ctx context.Context, profile *Profile) {
+	var startTime = time.Now()
+	var ticker = time.NewTicker(workersTick)
+	defer ticker.Stop()
+
+	for {
+		var target, stage, running = profile.TargetAt(time.Since(startTime))
+		l.mutex.Lock()
+		l.stage = stage
+		l.mutex.Unlock()
+		l.SetRate(target)
+
+		if !running {
+			return
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// refill adds tokens gathered since the last refill up to the burst
+func (l *Limiter) refill(now time.Time) {
+	if elapsed := now.Sub(l.lastRefill); elapsed > 0 {
+		l.tokens = math.Min(float64(l.burst), l.tokens+elapsed.Seconds()*l.rate)
+		l.lastRefill = now
+	}
+}
@@ -0,0 +1,87 @@
+package load
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLimiterWait(t *testing.T) {
+	var limiter = NewLimiter(100, 5)
+	var startTime = time.Now()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 5; j++ {
+				if errWait := limiter.Wait(context.Background()); errWait != nil {
+					t.Errorf("Unexpected error %v", errWait)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	// 5 requests of the burst are sent immediately and 15 more at a rate of 100/s
+	if elapsed := time.Since(startTime); elapsed < 140*time.Millisecond || elapsed > 500*time.Millisecond {
+		t.Errorf("Unexpected elapsed time %s", elapsed)
+	}
+}
+
+func TestLimiterWaitCancelled(t *testing.T) {
+	var limiter = NewLimiter(0, 1)
+	if errWait := limiter.Wait(context.Background()); errWait != nil {
+		t.Fatalf("Unexpected error %v", errWait)
+	}
+
+	var ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if errWait := limiter.Wait(ctx); errWait != context.DeadlineExceeded {
+		t.Errorf("Unexpected error %v", errWait)
+	}
+}
+
+func TestLimiterSetRate(t *testing.T) {
+	var limiter = NewLimiter(0, 1)
+	_ = limiter.Wait(context.Background())
+
+	var done = make(chan struct{})
+	go func() {
+		_ = limiter.Wait(context.Background())
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("A request is not expected to be sent at a zero rate")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	limiter.SetRate(100)
+	select {
+	case <-done:
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("A waiting request is expected to follow a new rate")
+	}
+
+	if limiter.Rate() != 100 {
+		t.Errorf("Unexpected rate %f", limiter.Rate())
+	}
+}
+
+func TestLimiterFollow(t *testing.T) {
+	var limiter = NewLimiter(0, 1)
+	var profile = NewProfile([]Stage{{Duration: time.Millisecond, Target: 50}, {Duration: time.Second, Target: 50}})
+
+	var ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	go limiter.Follow(ctx, profile)
+
+	time.Sleep(workersTick + 50*time.Millisecond)
+	if limiter.Rate() != 50 || limiter.Stage() != 2 {
+		t.Errorf("Unexpected rate %f of stage %d", limiter.Rate(), limiter.Stage())
+	}
+}
@@ -0,0 +1,38 @@
+package load
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseRate parses a rate of requests in a form of 'count/period', e.g. '500/s', '30/m' or '5/100ms', and returns
+// a number of requests per second. A rate without a period, e.g. '500', is a number of requests per second
+func ParseRate(value string) (float64, error) {
+	var countValue, periodValue = strings.TrimSpace(value), "s"
+	if separator := strings.Index(countValue, "/"); separator >= 0 {
+		countValue, periodValue = strings.TrimSpace(countValue[:separator]), strings.TrimSpace(countValue[separator+1:])
+	}
+
+	var count, errCount = strconv.ParseFloat(countValue, 64)
+	if errCount != nil || count <= 0 {
+		return 0, fmt.Errorf("a number of requests '%s' should be a positive number", countValue)
+	}
+
+	if periodValue == "" || periodValue[0] < '0' || periodValue[0] > '9' {
+		periodValue = "1" + periodValue
+	}
+
+	var period, errPeriod = time.ParseDuration(periodValue)
+	if errPeriod != nil || period <= 0 {
+		return 0, fmt.Errorf("a period '%s' should be a positive duration like 's', 'm' or '100ms'", periodValue)
+	}
+
+	return count / period.Seconds(), nil
+}
+
+// FormatRate returns a given number of requests per second in a form of 'count/s'
+func FormatRate(rate float64) string {
+	return strconv.FormatFloat(rate, 'f', -1, 64) + "/s"
+}
@@ -0,0 +1,39 @@
+package load
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseRate(t *testing.T) {
+	var givenRates = map[string]float64{
+		"500/s":   500,
+		"500":     500,
+		"30/m":    0.5,
+		"7200/h":  2,
+		"5/100ms": 50,
+		"1.5/2s":  0.75,
+		" 10 / s": 10,
+	}
+
+	for givenRate, expected := range givenRates {
+		var actual, errParse = ParseRate(givenRate)
+		if errParse != nil || math.Abs(actual-expected) > 1e-9 {
+			t.Errorf("Unexpected rate %v of '%s', error: %v", actual, givenRate, errParse)
+		}
+	}
+}
+
+func TestParseInvalidRate(t *testing.T) {
+	for _, givenRate := range []string{"", "s", "0/s", "-5/s", "10/x", "10/0s", "ten/s"} {
+		if _, errParse := ParseRate(givenRate); errParse == nil {
+			t.Errorf("An error is expected for rate '%s'", givenRate)
+		}
+	}
+}
+
+func TestFormatRate(t *testing.T) {
+	if actual := FormatRate(12.5); actual != "12.5/s" {
+		t.Errorf("Unexpected formatted rate '%s'", actual)
+	}
+}
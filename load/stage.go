@@ -0,0 +1,145 @@
+package load
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// StageTypeThreads is a type of stages which targets are numbers of concurrent threads (closed model)
+	StageTypeThreads = "threads"
+
+	// StageTypeRate is a type of stages which targets are rates in requests per second (open model)
+	StageTypeRate = "rate"
+
+	// StageTypeMaxRate is a type of stages which targets are maximum rates in requests per second of a Limiter
+	// shared by a constant number of threads
+	StageTypeMaxRate = "max-rate"
+)
+
+// StageTypes is a list of supported stage types
+var StageTypes = []string{StageTypeThreads, StageTypeRate, StageTypeMaxRate}
+
+// Stage is a part of a load profile during which a target changes linearly from a target of the previous stage
+// (or zero for the first stage) to the stage target
+type Stage struct {
+	Duration time.Duration
+	Target   float64
+}
+
+// ParseStage parses a stage in a form of 'duration:target', e.g. '30s:10' or '2m:200'
+func ParseStage(value string) (Stage, error) {
+	var separator = strings.LastIndex(value, ":")
+	if separator < 0 {
+		return Stage{}, fmt.Errorf("expected format is 'duration:target', e.g. '30s:10'")
+	}
+
+	var duration, errDuration = time.ParseDuration(strings.TrimSpace(value[:separator]))
+	if errDuration != nil || duration <= 0 {
+		return Stage{}, fmt.Errorf("duration '%s' should be a positive duration like '30s' or '2m'", strings.TrimSpace(value[:separator]))
+	}
+
+	var target, errTarget = strconv.ParseFloat(strings.TrimSpace(value[separator+1:]), 64)
+	if errTarget != nil || target < 0 || math.IsInf(target, 0) {
+		return Stage{}, fmt.Errorf("target '%s' should be a positive number or zero", strings.TrimSpace(value[separator+1:]))
+	}
+
+	return Stage{Duration: duration, Target: target}, nil
+}
+
+// String returns the stage in a form of 'duration:target'
+func (s Stage) String() string {
+	return fmt.Sprintf("%s:%s", s.Duration, strconv.FormatFloat(s.Target, 'f', -1, 64))
+}
+
+// Profile is a sequence of stages with linearly interpolated targets
+type Profile struct {
+	stages []Stage
+}
+
+// NewProfile creates a Profile of given stages
+func NewProfile(stages []Stage) *Profile {
+	return &Profile{stages: stages}
+}
+
+// Stages returns stages of the profile
+func (p *Profile) Stages() []Stage {
+	return p.stages
+}
+
+// Duration returns a total duration of all stages
+func (p *Profile) Duration() time.Duration {
+	var total time.Duration
+	for _, stage := range p.stages {
+		total += stage.Duration
+	}
+	return total
+}
+
+// MaxTarget returns the highest target of all stages
+func (p *Profile) MaxTarget() float64 {
+	var max float64
+	for _, stage := range p.stages {
+		max = math.Max(max, stage.Target)
+	}
+	return max
+}
+
+// Jobs returns a total number of jobs scheduled by Next when targets of stages are rates in jobs per second
+func (p *Profile) Jobs() int {
+	var total float64
+	var previousTarget float64
+	for _, stage := range p.stages {
+		total += (previousTarget + stage.Target) / 2 * stage.Duration.Seconds()
+		previousTarget = stage.Target
+	}
+	return int(math.Ceil(total))
+}
+
+// TargetAt returns an interpolated target at a given time elapsed since the start of the profile and a number
+// of the stage this time belongs to starting from 1. The returned boolean is false when the profile is over
+func (p *Profile) TargetAt(elapsed time.Duration) (float64, int, bool) {
+	var previousTarget float64
+	for i, stage := range p.stages {
+		if elapsed < stage.Duration {
+			var progress = float64(elapsed) / float64(stage.Duration)
+			return previousTarget + (stage.Target-previousTarget)*progress, i + 1, true
+		}
+
+		elapsed -= stage.Duration
+		previousTarget = stage.Target
+	}
+	return previousTarget, len(p.stages), false
+}
+
+// Next returns a time offset since the start of the profile at which a job with a given iteration is scheduled when
+// targets of stages are rates in jobs per second, and a number of its stage. A number of jobs scheduled up to any moment
+// is an integral of the interpolated rate. The returned boolean is false when the job doesn't fit into the profile
+func (p *Profile) Next(iteration int) (time.Duration, int, bool) {
+	var remaining = float64(iteration)
+	var offset time.Duration
+	var previousTarget float64
+
+	for i, stage := range p.stages {
+		var seconds = stage.Duration.Seconds()
+		var stageJobs = (previousTarget + stage.Target) / 2 * seconds
+		if remaining < stageJobs {
+			// solve 'previousTarget*t + slope*t*t/2 = remaining' for the time 't' within the stage
+			var slope = (stage.Target - previousTarget) / seconds
+			var discriminant = math.Max(previousTarget*previousTarget+2*slope*remaining, 0)
+			var elapsed = 2 * remaining / (previousTarget + math.Sqrt(discriminant))
+			if math.IsNaN(elapsed) {
+				elapsed = 0
+			}
+			return offset + time.Duration(elapsed*float64(time.Second)), i + 1, true
+		}
+
+		remaining -= stageJobs
+		offset += stage.Duration
+		previousTarget = stage.Target
+	}
+	return 0, 0, false
+}
@@ -0,0 +1,133 @@
+package load
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestParseStage(t *testing.T) {
+	var givenStages = map[string]Stage{
+		"30s:10":     {Duration: 30 * time.Second, Target: 10},
+		"2m:200":     {Duration: 2 * time.Minute, Target: 200},
+		" 1m30s : 0": {Duration: 90 * time.Second, Target: 0},
+		"500ms:2.5":  {Duration: 500 * time.Millisecond, Target: 2.5},
+	}
+
+	for givenStage, expected := range givenStages {
+		var actual, errParse = ParseStage(givenStage)
+		if errParse != nil || actual != expected {
+			t.Errorf("Unexpected stage %v of '%s', error: %v", actual, givenStage, errParse)
+		}
+	}
+}
+
+func TestParseInvalidStage(t *testing.T) {
+	for _, givenStage := range []string{"", "30s", "30:10", "0s:10", "-5s:10", "30s:-1", "30s:ten", "30s:"} {
+		if _, errParse := ParseStage(givenStage); errParse == nil {
+			t.Errorf("An error is expected for stage '%s'", givenStage)
+		}
+	}
+}
+
+func TestStageString(t *testing.T) {
+	if actual := (Stage{Duration: 30 * time.Second, Target: 2.5}).String(); actual != "30s:2.5" {
+		t.Errorf("Unexpected stage string '%s'", actual)
+	}
+}
+
+func TestProfileTargetAt(t *testing.T) {
+	var profile = NewProfile([]Stage{{Duration: 10 * time.Second, Target: 10}, {Duration: 10 * time.Second, Target: 10}, {Duration: 5 * time.Second, Target: 0}})
+	var expectations = []struct {
+		elapsed time.Duration
+		target  float64
+		stage   int
+		running bool
+	}{
+		{0, 0, 1, true},
+		{5 * time.Second, 5, 1, true},
+		{10 * time.Second, 10, 2, true},
+		{19 * time.Second, 10, 2, true},
+		{22500 * time.Millisecond, 5, 3, true},
+		{25 * time.Second, 0, 3, false},
+	}
+
+	for _, expected := range expectations {
+		var target, stage, running = profile.TargetAt(expected.elapsed)
+		if math.Abs(target-expected.target) > 1e-9 || stage != expected.stage || running != expected.running {
+			t.Errorf("Unexpected target %v, stage %d or running %t at %s", target, stage, running, expected.elapsed)
+		}
+	}
+
+	if profile.Duration() != 25*time.Second || profile.MaxTarget() != 10 {
+		t.Errorf("Unexpected duration %s or max target %v", profile.Duration(), profile.MaxTarget())
+	}
+}
+
+func TestProfileNextOfConstantStage(t *testing.T) {
+	var profile = NewProfile([]Stage{{Duration: time.Second, Target: 10}, {Duration: time.Second, Target: 10}})
+
+	// the first stage ramps up from 0 to 10 jobs per second, so it holds 5 jobs
+	var expectedOffsets = map[int]time.Duration{
+		0:  0,
+		5:  time.Second,
+		10: 1500 * time.Millisecond,
+		14: 1900 * time.Millisecond,
+	}
+
+	for iteration, expected := range expectedOffsets {
+		var offset, _, scheduled = profile.Next(iteration)
+		if !scheduled || (offset-expected).Round(time.Millisecond) != 0 {
+			t.Errorf("Unexpected offset %s of iteration %d", offset, iteration)
+		}
+	}
+
+	if _, stage, _ := profile.Next(4); stage != 1 {
+		t.Errorf("Unexpected stage %d of iteration 4", stage)
+	}
+
+	if _, stage, _ := profile.Next(5); stage != 2 {
+		t.Errorf("Unexpected stage %d of iteration 5", stage)
+	}
+
+	if _, _, scheduled := profile.Next(15); scheduled {
+		t.Errorf("Iteration 15 is not expected to fit into the profile")
+	}
+
+	if profile.Jobs() != 15 {
+		t.Errorf("Unexpected number of jobs %d", profile.Jobs())
+	}
+}
+
+func TestProfileNextOfRamp(t *testing.T) {
+	var profile = NewProfile([]Stage{{Duration: 2 * time.Second, Target: 10}})
+
+	// a number of jobs scheduled up to a time 't' of a ramp from 0 to 10 jobs per second over 2 seconds is 2.5*t*t
+	var offset, _, _ = profile.Next(5)
+	if math.Abs(offset.Seconds()-math.Sqrt2) > 1e-6 {
+		t.Errorf("Unexpected offset %s of iteration 5", offset)
+	}
+
+	var previous time.Duration
+	for i := 1; i < 10; i++ {
+		var next, _, scheduled = profile.Next(i)
+		if !scheduled || next <= previous {
+			t.Errorf("Unexpected offset %s of iteration %d after %s", next, i, previous)
+		}
+		previous = next
+	}
+}
+
+func TestProfileNextOfRampDown(t *testing.T) {
+	var profile = NewProfile([]Stage{{Duration: time.Second, Target: 10}, {Duration: time.Second, Target: 0}})
+
+	for i := 0; i < 10; i++ {
+		if _, _, scheduled := profile.Next(i); !scheduled {
+			t.Errorf("Iteration %d is expected to fit into the profile", i)
+		}
+	}
+
+	if _, _, scheduled := profile.Next(10); scheduled {
+		t.Errorf("Iteration 10 is not expected to fit into the profile")
+	}
+}
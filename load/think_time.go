@@ -0,0 +1,161 @@
+package load
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// ThinkTimeFixed is a distribution of think times which are always the same, e.g. '250ms'
+	ThinkTimeFixed = "fixed"
+
+	// ThinkTimeUniform is a distribution of think times which are uniformly spread in a range, e.g. '100ms..500ms'
+	ThinkTimeUniform = "uniform"
+
+	// ThinkTimeNormal is a normal distribution of think times with a mean and a standard deviation, e.g. 'normal:300ms,50ms'
+	ThinkTimeNormal = "normal"
+
+	// ThinkTimeExponential is an exponential distribution of think times with a mean, e.g. 'exponential:300ms'.
+	// Pauses of this distribution make requests of a thread a Poisson process
+	ThinkTimeExponential = "exponential"
+
+	// ThinkTimeTemplate is a distribution of think times which are taken from a template column, e.g. 'template:think_ms'
+	ThinkTimeTemplate = "template"
+)
+
+// ThinkTime describes a pause of a thread after every request which imitates a time a real user spends between actions
+type ThinkTime struct {
+	Distribution string
+
+	// Min and Max are bounds of a uniform distribution, Min is also a value of a fixed distribution
+	Min time.Duration
+	Max time.Duration
+
+	// Mean is a mean of normal and exponential distributions, StdDev is a standard deviation of a normal distribution
+	Mean   time.Duration
+	StdDev time.Duration
+
+	// Key is a placeholder key of a template value of a template distribution
+	Key string
+}
+
+// ParseThinkTime parses a think time in one of forms: '250ms' (fixed), '100ms..500ms' (uniform),
+// 'normal:300ms,50ms' (mean and standard deviation), 'exponential:300ms' or 'poisson:300ms' (mean)
+// and 'template:key' (a template value of a given placeholder key)
+func ParseThinkTime(value string) (*ThinkTime, error) {
+	var trimmed = strings.TrimSpace(value)
+	var distribution, arguments = "", trimmed
+	if separator := strings.Index(trimmed, ":"); separator >= 0 {
+		distribution, arguments = strings.ToLower(strings.TrimSpace(trimmed[:separator])), strings.TrimSpace(trimmed[separator+1:])
+	}
+
+	switch distribution {
+	case "":
+		if separator := strings.Index(arguments, ".."); separator >= 0 {
+			var min, errMin = parseThinkDuration(arguments[:separator])
+			var max, errMax = parseThinkDuration(arguments[separator+2:])
+			if errMin != nil || errMax != nil || min > max {
+				return nil, fmt.Errorf("a range '%s' should consist of two durations where the first one is not greater than the second one, e.g. '100ms..500ms'", arguments)
+			}
+			return &ThinkTime{Distribution: ThinkTimeUniform, Min: min, Max: max}, nil
+		}
+
+		var fixed, errFixed = parseThinkDuration(arguments)
+		if errFixed != nil {
+			return nil, errFixed
+		}
+		return &ThinkTime{Distribution: ThinkTimeFixed, Min: fixed}, nil
+
+	case ThinkTimeNormal:
+		var parameters = strings.Split(arguments, ",")
+		if len(parameters) != 2 {
+			return nil, fmt.Errorf("a normal distribution '%s' should have a mean and a standard deviation, e.g. 'normal:300ms,50ms'", arguments)
+		}
+
+		var mean, errMean = parseThinkDuration(parameters[0])
+		if errMean != nil {
+			return nil, errMean
+		}
+		var stdDev, errStdDev = parseThinkDuration(parameters[1])
+		if errStdDev != nil {
+			return nil, errStdDev
+		}
+		return &ThinkTime{Distribution: ThinkTimeNormal, Mean: mean, StdDev: stdDev}, nil
+
+	case ThinkTimeExponential, "exp", "poisson":
+		var mean, errMean = parseThinkDuration(arguments)
+		if errMean != nil {
+			return nil, errMean
+		}
+		return &ThinkTime{Distribution: ThinkTimeExponential, Mean: mean}, nil
+
+	case ThinkTimeTemplate:
+		if arguments == "" {
+			return nil, fmt.Errorf("a template distribution should have a placeholder key, e.g. 'template:think_ms'")
+		}
+		return &ThinkTime{Distribution: ThinkTimeTemplate, Key: arguments}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown distribution '%s', expected one of: %s, %s, %s",
+			distribution, ThinkTimeNormal, ThinkTimeExponential, ThinkTimeTemplate)
+	}
+}
+
+// ParseThinkTimeValue parses a single think time value which is a duration, e.g. '250ms', or a number of milliseconds
+func ParseThinkTimeValue(value string) (time.Duration, error) {
+	var trimmed = strings.TrimSpace(value)
+	if milliseconds, errNumber := strconv.ParseFloat(trimmed, 64); errNumber == nil {
+		if milliseconds < 0 || math.IsInf(milliseconds, 0) || math.IsNaN(milliseconds) {
+			return 0, fmt.Errorf("a think time '%s' should not be negative", trimmed)
+		}
+		return time.Duration(milliseconds * float64(time.Millisecond)), nil
+	}
+
+	return parseThinkDuration(trimmed)
+}
+
+func parseThinkDuration(value string) (time.Duration, error) {
+	var duration, errParse = time.ParseDuration(strings.TrimSpace(value))
+	if errParse != nil || duration < 0 {
+		return 0, fmt.Errorf("'%s' should be a positive duration or zero like '250ms' or '1s'", strings.TrimSpace(value))
+	}
+	return duration, nil
+}
+
+// Next returns a random think time of the distribution generated by a given random source. Negative values
+// of a normal distribution are clipped to zero. A think time of a template distribution should be obtained
+// with ParseThinkTimeValue instead, so zero is returned for it
+func (t *ThinkTime) Next(random *rand.Rand) time.Duration {
+	switch t.Distribution {
+	case ThinkTimeFixed:
+		return t.Min
+	case ThinkTimeUniform:
+		return t.Min + time.Duration(random.Int63n(int64(t.Max-t.Min)+1))
+	case ThinkTimeNormal:
+		return time.Duration(math.Max(random.NormFloat64()*float64(t.StdDev)+float64(t.Mean), 0))
+	case ThinkTimeExponential:
+		return time.Duration(random.ExpFloat64() * float64(t.Mean))
+	default:
+		return 0
+	}
+}
+
+// String returns the think time in a form accepted by ParseThinkTime
+func (t *ThinkTime) String() string {
+	switch t.Distribution {
+	case ThinkTimeFixed:
+		return t.Min.String()
+	case ThinkTimeUniform:
+		return fmt.Sprintf("%s..%s", t.Min, t.Max)
+	case ThinkTimeNormal:
+		return fmt.Sprintf("%s:%s,%s", ThinkTimeNormal, t.Mean, t.StdDev)
+	case ThinkTimeExponential:
+		return fmt.Sprintf("%s:%s", ThinkTimeExponential, t.Mean)
+	default:
+		return fmt.Sprintf("%s:%s", ThinkTimeTemplate, t.Key)
+	}
+}
@@ -0,0 +1,85 @@
+package load
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestParseThinkTime(t *testing.T) {
+	var givenThinkTimes = map[string]ThinkTime{
+		"250ms":              {Distribution: ThinkTimeFixed, Min: 250 * time.Millisecond},
+		"100ms..500ms":       {Distribution: ThinkTimeUniform, Min: 100 * time.Millisecond, Max: 500 * time.Millisecond},
+		"normal:300ms, 50ms": {Distribution: ThinkTimeNormal, Mean: 300 * time.Millisecond, StdDev: 50 * time.Millisecond},
+		"exponential:1s":     {Distribution: ThinkTimeExponential, Mean: time.Second},
+		"Poisson:1s":         {Distribution: ThinkTimeExponential, Mean: time.Second},
+		"template:think_ms":  {Distribution: ThinkTimeTemplate, Key: "think_ms"},
+	}
+
+	for givenThinkTime, expected := range givenThinkTimes {
+		var actual, errParse = ParseThinkTime(givenThinkTime)
+		if errParse != nil || *actual != expected {
+			t.Errorf("Unexpected think time %v of '%s', error: %v", actual, givenThinkTime, errParse)
+		}
+	}
+}
+
+func TestParseInvalidThinkTime(t *testing.T) {
+	for _, givenThinkTime := range []string{"", "250", "-1s", "500ms..100ms", "1s..", "normal:300ms", "normal:300ms,x", "exp:", "template:", "gamma:1s"} {
+		if _, errParse := ParseThinkTime(givenThinkTime); errParse == nil {
+			t.Errorf("An error is expected for think time '%s'", givenThinkTime)
+		}
+	}
+}
+
+func TestParseThinkTimeValue(t *testing.T) {
+	var expectations = map[string]time.Duration{"250": 250 * time.Millisecond, "1.5": 1500 * time.Microsecond, "2s": 2 * time.Second, " 0 ": 0}
+
+	for given, expected := range expectations {
+		if actual, errParse := ParseThinkTimeValue(given); errParse != nil || actual != expected {
+			t.Errorf("Unexpected think time value %s of '%s', error: %v", actual, given, errParse)
+		}
+	}
+
+	for _, given := range []string{"", "-5", "soon", "-1s"} {
+		if _, errParse := ParseThinkTimeValue(given); errParse == nil {
+			t.Errorf("An error is expected for think time value '%s'", given)
+		}
+	}
+}
+
+func TestThinkTimeNext(t *testing.T) {
+	var random = rand.New(rand.NewSource(1))
+	var samples = 10000
+	var givenThinkTimes = []*ThinkTime{
+		{Distribution: ThinkTimeFixed, Min: 250 * time.Millisecond},
+		{Distribution: ThinkTimeUniform, Min: 200 * time.Millisecond, Max: 300 * time.Millisecond},
+		{Distribution: ThinkTimeNormal, Mean: 250 * time.Millisecond, StdDev: 20 * time.Millisecond},
+		{Distribution: ThinkTimeExponential, Mean: 250 * time.Millisecond},
+	}
+
+	for _, thinkTime := range givenThinkTimes {
+		var total time.Duration
+		for i := 0; i < samples; i++ {
+			var next = thinkTime.Next(random)
+			if next < 0 || thinkTime.Distribution == ThinkTimeUniform && (next < thinkTime.Min || next > thinkTime.Max) {
+				t.Fatalf("Unexpected think time %s of '%s'", next, thinkTime)
+			}
+			total += next
+		}
+
+		if mean := total / time.Duration(samples); math.Abs(float64(mean-250*time.Millisecond)) > float64(10*time.Millisecond) {
+			t.Errorf("Unexpected mean %s of '%s'", mean, thinkTime)
+		}
+	}
+}
+
+func TestThinkTimeString(t *testing.T) {
+	for _, given := range []string{"250ms", "100ms..500ms", "normal:300ms,50ms", "exponential:1s", "template:think_ms"} {
+		var thinkTime, _ = ParseThinkTime(given)
+		if thinkTime.String() != given {
+			t.Errorf("Unexpected think time string '%s' of '%s'", thinkTime.String(), given)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package load
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// workersTick is an interval at which a number of running workers is adjusted to an interpolated target
+const workersTick = 100 * time.Millisecond
+
+// Workers runs a varying number of concurrent workers which follows interpolated targets of a profile (closed model).
+// Workers are started and stopped in order of their ids, so workers with the lowest ids run the longest
+type Workers struct {
+	profile *Profile
+	tick    time.Duration
+
+	mutex      sync.Mutex
+	target     int
+	stage      int
+	stopped    bool
+	alive      []bool
+	iterations []int
+	waitGroup  sync.WaitGroup
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewWorkers creates Workers of a given profile which targets are numbers of concurrent workers
+func NewWorkers(profile *Profile) *Workers {
+	var maxWorkers = int(math.Ceil(profile.MaxTarget()))
+	return &Workers{
+		profile:    profile,
+		tick:       workersTick,
+		alive:      make([]bool, maxWorkers),
+		iterations: make([]int, maxWorkers),
+		stop:       make(chan struct{}),
+	}
+}
+
+// Target returns a current number of workers which should be running and a number of the current stage starting from 1
+func (w *Workers) Target() (int, int) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.target, w.stage
+}
+
+// Stop stops all workers once they complete their current iterations. It's safe to call Stop multiple times
+func (w *Workers) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stop)
+	})
+}
+
+// Run runs workers until the profile is over or Stop is called. Every worker calls a given function with its id,
+// a number of its iteration and a number of the current stage repeatedly, when the function returns false all workers
+// are stopped, e.g. when template lines are exhausted. It blocks until all workers complete their current iterations
+func (w *Workers) Run(iterate func(workerID int, iteration int, stage int) bool) {
+	var startTime = time.Now()
+	var ticker = time.NewTicker(w.tick)
+	defer ticker.Stop()
+
+profile:
+	for {
+		var target, stage, running = w.profile.TargetAt(time.Since(startTime))
+		if !running {
+			break
+		}
+
+		w.scale(int(math.Round(target)), stage, iterate)
+
+		select {
+		case <-ticker.C:
+		case <-w.stop:
+			break profile
+		}
+	}
+
+	w.mutex.Lock()
+	w.stopped = true
+	w.target = 0
+	w.mutex.Unlock()
+
+	w.waitGroup.Wait()
+}
+
+// scale sets a given target and stage and starts workers which are expected to run but are not alive
+func (w *Workers) scale(target int, stage int, iterate func(workerID int, iteration int, stage int) bool) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.stopped {
+		return
+	}
+
+	w.target, w.stage = target, stage
+	for workerID := 0; workerID < target && workerID < len(w.alive); workerID++ {
+		if !w.alive[workerID] {
+			w.alive[workerID] = true
+			w.waitGroup.Add(1)
+			go w.work(workerID, iterate)
+		}
+	}
+}
+
+// work performs iterations of a worker with a given id while the id is within the current target
+func (w *Workers) work(workerID int, iterate func(workerID int, iteration int, stage int) bool) {
+	defer w.waitGroup.Done()
+
+	for {
+		w.mutex.Lock()
+		if workerID >= w.target {
+			w.alive[workerID] = false
+			w.mutex.Unlock()
+			return
+		}
+
+		var iteration, stage = w.iterations[workerID], w.stage
+		w.iterations[workerID]++
+		w.mutex.Unlock()
+
+		if !iterate(workerID, iteration, stage) {
+			w.mutex.Lock()
+			w.stopped = true
+			w.target = 0
+			w.alive[workerID] = false
+			w.mutex.Unlock()
+
+			w.Stop()
+			return
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package load
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWorkersFollowTargets(t *testing.T) {
+	var profile = NewProfile([]Stage{{Duration: 50 * time.Millisecond, Target: 4}, {Duration: 100 * time.Millisecond, Target: 4}, {Duration: 100 * time.Millisecond, Target: 1}})
+	var workers = NewWorkers(profile)
+	workers.tick = 5 * time.Millisecond
+
+	var mutex = &sync.Mutex{}
+	var active, maxActive int
+	var stages = make(map[int]bool)
+	var workerIDs = make(map[int]bool)
+
+	workers.Run(func(workerID int, iteration int, stage int) bool {
+		mutex.Lock()
+		active++
+		if active > maxActive {
+			maxActive = active
+		}
+		stages[stage] = true
+		workerIDs[workerID] = true
+		mutex.Unlock()
+
+		time.Sleep(time.Millisecond)
+
+		mutex.Lock()
+		active--
+		mutex.Unlock()
+		return true
+	})
+
+	if maxActive != 4 || len(workerIDs) != 4 {
+		t.Errorf("Unexpected max number of active workers %d or workers %v", maxActive, workerIDs)
+	}
+
+	if !stages[1] || !stages[2] || !stages[3] {
+		t.Errorf("Unexpected stages %v", stages)
+	}
+
+	if target, _ := workers.Target(); target != 0 {
+		t.Errorf("Unexpected target %d after the profile is over", target)
+	}
+}
+
+func TestWorkersStopWhenIterationFails(t *testing.T) {
+	var workers = NewWorkers(NewProfile([]Stage{{Duration: time.Millisecond, Target: 2}, {Duration: time.Minute, Target: 2}}))
+	workers.tick = time.Millisecond
+
+	var mutex = &sync.Mutex{}
+	var performed = 0
+	var startTime = time.Now()
+
+	workers.Run(func(workerID int, iteration int, stage int) bool {
+		time.Sleep(time.Millisecond)
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		performed++
+		return performed < 10
+	})
+
+	if time.Since(startTime) > 10*time.Second || performed < 10 {
+		t.Errorf("Unexpected number of performed iterations %d in %s", performed, time.Since(startTime))
+	}
+}
+
+func TestWorkersStop(t *testing.T) {
+	var workers = NewWorkers(NewProfile([]Stage{{Duration: time.Millisecond, Target: 3}, {Duration: time.Minute, Target: 3}}))
+	workers.tick = time.Millisecond
+
+	var iterations = make([]int, 3)
+	var mutex = &sync.Mutex{}
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		workers.Stop()
+		workers.Stop()
+	}()
+
+	workers.Run(func(workerID int, iteration int, stage int) bool {
+		mutex.Lock()
+		if iteration != iterations[workerID] {
+			t.Errorf("Unexpected iteration %d of worker %d", iteration, workerID)
+		}
+		iterations[workerID]++
+		mutex.Unlock()
+
+		time.Sleep(time.Millisecond)
+		return true
+	})
+}
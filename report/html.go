@@ -0,0 +1,204 @@
+package report
+
+import (
+	"fmt"
+	"github.com/vkrava4/curlson/stats"
+	"html/template"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const distributionBins = 40
+
+// htmlReport holds data rendered into a self-contained HTML report
+type htmlReport struct {
+	Run            Run
+	Summary        *stats.Summary
+	GeneratedAt    string
+	Configuration  [][2]string
+	LatencyChart   template.HTML
+	RPSChart       template.HTML
+	HistogramChart template.HTML
+	StatusChart    template.HTML
+}
+
+// WriteHTML renders a self-contained HTML report with charts built from a given timeline. The page doesn't
+// reference any external resources so it can be opened offline
+func WriteHTML(w io.Writer, run Run, summary *stats.Summary, timeline *Timeline) error {
+	var data = &htmlReport{
+		Run:         run,
+		Summary:     summary,
+		GeneratedAt: time.Now().Format(time.RFC1123),
+	}
+
+	var keys = make([]string, 0, len(run.Configuration))
+	for key := range run.Configuration {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		data.Configuration = append(data.Configuration, [2]string{key, run.Configuration[key]})
+	}
+
+	var points = timeline.Points()
+	var p50, p95, p99, maxLatency, requests, failures = make([]float64, len(points)), make([]float64, len(points)),
+		make([]float64, len(points)), make([]float64, len(points)), make([]float64, len(points)), make([]float64, len(points))
+	for i, point := range points {
+		p50[i], p95[i], p99[i], maxLatency[i] = Milliseconds(point.P50), Milliseconds(point.P95), Milliseconds(point.P99), Milliseconds(point.Max)
+		requests[i], failures[i] = float64(point.Requests), float64(point.Failures)
+	}
+
+	data.LatencyChart = lineChartSVG([]chartSeries{
+		{Name: "p50", Color: chartPalette[0], Values: p50},
+		{Name: "p95", Color: chartPalette[2], Values: p95},
+		{Name: "p99", Color: chartPalette[3], Values: p99},
+		{Name: "max", Color: chartPalette[7], Values: maxLatency},
+	}, "elapsed, s", "latency, ms")
+
+	data.RPSChart = lineChartSVG([]chartSeries{
+		{Name: "requests", Color: chartPalette[1], Values: requests},
+		{Name: "failures", Color: chartPalette[3], Values: failures},
+	}, "elapsed, s", "requests per second")
+
+	var bins = timeline.Distribution(distributionBins)
+	var binValues, binLabels = make([]float64, len(bins)), make([]string, len(bins))
+	for i, bin := range bins {
+		binValues[i] = float64(bin.Count)
+		binLabels[i] = formatFloat(Milliseconds(bin.From))
+	}
+	data.HistogramChart = barChartSVG(binValues, binLabels, "latency, ms", "requests")
+
+	var slices []chartSlice
+	for i, statusCode := range summary.StatusCodes {
+		slices = append(slices, chartSlice{Label: strconv.Itoa(statusCode.StatusCode), Color: chartPalette[i%len(chartPalette)], Value: float64(statusCode.Count)})
+	}
+	for _, errorCount := range summary.Errors {
+		slices = append(slices, chartSlice{Label: string(errorCount.Class), Color: chartPalette[len(slices)%len(chartPalette)], Value: float64(errorCount.Count)})
+	}
+	data.StatusChart = pieChartSVG(slices)
+
+	return htmlTemplate.Execute(w, data)
+}
+
+// WriteHTMLFile renders an HTML report into a file with a given path
+func WriteHTMLFile(path string, run Run, summary *stats.Summary, timeline *Timeline) error {
+	var file, errCreate = os.Create(path)
+	if errCreate != nil {
+		return errCreate
+	}
+
+	var errWrite = WriteHTML(file, run, summary, timeline)
+	if errClose := file.Close(); errWrite == nil {
+		errWrite = errClose
+	}
+
+	return errWrite
+}
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"ms":      func(d time.Duration) string { return fmt.Sprintf("%.3f ms", Milliseconds(d)) },
+	"rfc3339": func(t time.Time) string { return t.Format(time.RFC3339) },
+	"percent": func(count int64, total int64) string {
+		if total == 0 {
+			return "0.00%"
+		}
+		return fmt.Sprintf("%.2f%%", float64(count)/float64(total)*100)
+	},
+	"ratio": func(ratio float64) string { return fmt.Sprintf("%.2f%%", ratio*100) },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Curlson report: {{.Run.Command}} {{.Run.URL}}</title>
+<style>
+	body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 0; background: #f5f6f8; color: #263238; }
+	header { background: #263238; color: #fff; padding: 20px 32px; }
+	header h1 { margin: 0 0 6px 0; font-size: 22px; }
+	header p { margin: 0; color: #b0bec5; word-break: break-all; }
+	main { padding: 24px 32px; max-width: 1100px; }
+	section { background: #fff; border-radius: 6px; box-shadow: 0 1px 3px rgba(0,0,0,.12); padding: 16px 20px; margin-bottom: 20px; }
+	h2 { font-size: 16px; margin: 0 0 12px 0; }
+	table { border-collapse: collapse; width: 100%; font-size: 14px; }
+	th, td { text-align: left; padding: 6px 10px; border-bottom: 1px solid #eceff1; }
+	th { color: #607d8b; font-weight: 600; }
+	.tiles { display: flex; flex-wrap: wrap; gap: 12px; }
+	.tile { flex: 1 1 150px; background: #eceff1; border-radius: 4px; padding: 10px 14px; }
+	.tile .value { font-size: 20px; font-weight: 600; }
+	.tile .name { font-size: 12px; color: #607d8b; }
+	.interrupted { background: #fff3e0; color: #e65100; border-radius: 6px; padding: 12px 20px; margin-bottom: 20px; }
+	.chart { width: 100%; height: auto; font-size: 11px; }
+	.chart .grid { stroke: #eceff1; }
+	.chart .axis { stroke: #90a4ae; }
+	.chart .tick, .chart .muted { fill: #78909c; }
+	.chart .label { fill: #455a64; font-weight: 600; }
+	.chart text { font-family: inherit; }
+</style>
+</head>
+<body>
+<header>
+	<h1>Curlson report</h1>
+	<p>{{.Run.Command}} {{.Run.URL}} &middot; {{rfc3339 .Summary.StartTime}} &ndash; {{rfc3339 .Summary.EndTime}} &middot; generated {{.GeneratedAt}}</p>
+</header>
+<main>
+{{if .Summary.Interrupted}}<div class="interrupted">Execution was interrupted, the report covers completed requests only</div>{{end}}
+<section>
+	<h2>Overview</h2>
+	<div class="tiles">
+		<div class="tile"><div class="value">{{.Summary.Requests}}</div><div class="name">requests</div></div>
+		<div class="tile"><div class="value">{{printf "%.2f" .Summary.Throughput}}</div><div class="name">requests per second</div></div>
+		<div class="tile"><div class="value">{{ms .Summary.Duration}}</div><div class="name">wall-clock time</div></div>
+		<div class="tile"><div class="value">{{.Summary.TransportErrors}}</div><div class="name">transport errors</div></div>
+		{{if .Summary.ThinkTime.Pauses}}<div class="tile"><div class="value">{{ms .Summary.ThinkTime.Distribution.Mean}}</div><div class="name">mean think time, excluded from latency</div></div>{{end}}
+		{{with .Summary.Connections}}{{if or .New .Reused}}<div class="tile"><div class="value">{{ratio .ReuseRatio}}</div><div class="name">connection reuse</div></div>{{end}}{{end}}
+	</div>
+</section>
+<section>
+	<h2>Latency</h2>
+	<table>
+		<tr><th>min</th><th>mean</th><th>max</th><th>stddev</th><th>p50</th><th>p90</th><th>p95</th><th>p99</th><th>p99.9</th></tr>
+		{{with .Summary.Latency}}<tr><td>{{ms .Min}}</td><td>{{ms .Mean}}</td><td>{{ms .Max}}</td><td>{{ms .StdDev}}</td><td>{{ms .P50}}</td><td>{{ms .P90}}</td><td>{{ms .P95}}</td><td>{{ms .P99}}</td><td>{{ms .P999}}</td></tr>{{end}}
+	</table>
+</section>
+{{if .Summary.Stages}}<section>
+	<h2>Stages</h2>
+	<table>
+		<tr><th>stage</th><th>requests</th><th>failures</th><th>duration</th><th>rps</th><th>mean</th><th>p50</th><th>p95</th><th>p99</th><th>max</th></tr>
+		{{range .Summary.Stages}}<tr><td>{{.Stage}}</td><td>{{.Requests}}</td><td>{{.Failures}}</td><td>{{ms .Duration}}</td><td>{{printf "%.2f" .Throughput}}</td><td>{{ms .Latency.Mean}}</td><td>{{ms .Latency.P50}}</td><td>{{ms .Latency.P95}}</td><td>{{ms .Latency.P99}}</td><td>{{ms .Latency.Max}}</td></tr>
+		{{end}}
+	</table>
+</section>{{end}}
+<section>
+	<h2>Latency over time</h2>
+	{{.LatencyChart}}
+</section>
+<section>
+	<h2>Requests per second</h2>
+	{{.RPSChart}}
+</section>
+<section>
+	<h2>Latency distribution</h2>
+	{{.HistogramChart}}
+</section>
+<section>
+	<h2>Responses</h2>
+	{{.StatusChart}}
+	<table>
+		<tr><th>class</th><th>count</th><th>share</th><th>mean</th><th>p50</th><th>p95</th><th>p99</th><th>max</th></tr>
+		{{$total := .Summary.Requests}}{{range .Summary.Classes}}<tr><td>{{.Class}}</td><td>{{.Count}}</td><td>{{percent .Count $total}}</td><td>{{ms .Latency.Mean}}</td><td>{{ms .Latency.P50}}</td><td>{{ms .Latency.P95}}</td><td>{{ms .Latency.P99}}</td><td>{{ms .Latency.Max}}</td></tr>
+		{{end}}
+	</table>
+</section>
+{{if .Configuration}}<section>
+	<h2>Configuration</h2>
+	<table>
+		{{range .Configuration}}<tr><th>{{index . 0}}</th><td>{{index . 1}}</td></tr>
+		{{end}}
+	</table>
+</section>{{end}}
+</main>
+</body>
+</html>
+`))
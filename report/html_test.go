@@ -0,0 +1,93 @@
+package report
+
+import (
+	"bytes"
+	"github.com/vkrava4/curlson/stats"
+	"strings"
+	"testing"
+)
+
+func TestWriteHTML(t *testing.T) {
+	var timeline = NewTimeline()
+	for _, result := range givenResults() {
+		_ = timeline.WriteResult(result)
+	}
+
+	var buffer = &bytes.Buffer{}
+	var givenRun = Run{Command: "get", URL: "http://localhost/<script>", Configuration: map[string]string{"threads": "2"}}
+
+	if errWrite := WriteHTML(buffer, givenRun, givenSummary(), timeline); errWrite != nil {
+		t.Fatalf("An error is not expected: %v", errWrite)
+	}
+
+	var output = buffer.String()
+	if strings.Count(output, "<svg") != 4 || strings.Contains(output, "&lt;svg") {
+		t.Error("HTML report should contain 4 inline SVG charts")
+	}
+
+	if strings.Contains(output, "<script>") || !strings.Contains(output, "http://localhost/&lt;script&gt;") {
+		t.Error("HTML report should escape run details")
+	}
+
+	if strings.Contains(output, "src=\"http") || strings.Contains(output, "href=\"http") {
+		t.Error("HTML report should not reference external resources")
+	}
+
+	if !strings.Contains(output, "<h2>Stages</h2>") || !strings.Contains(output, "<tr><td>1</td><td>3</td><td>1</td>") {
+		t.Error("HTML report should contain statistics of stages")
+	}
+
+	if !strings.Contains(output, "mean think time") {
+		t.Error("HTML report should contain think time")
+	}
+
+	if !strings.Contains(output, "75.00%</div><div class=\"name\">connection reuse") {
+		t.Error("HTML report should contain connection reuse")
+	}
+
+	if !strings.Contains(output, "Execution was interrupted") {
+		t.Error("HTML report should mark interrupted execution")
+	}
+
+	if !strings.Contains(output, "<th>threads</th><td>2</td>") {
+		t.Error("HTML report should contain run configuration")
+	}
+}
+
+func TestWriteHTMLForEmptyRun(t *testing.T) {
+	var buffer = &bytes.Buffer{}
+
+	if errWrite := WriteHTML(buffer, Run{}, &stats.Summary{}, NewTimeline()); errWrite != nil {
+		t.Fatalf("An error is not expected: %v", errWrite)
+	}
+
+	if strings.Count(buffer.String(), "No data") != 4 {
+		t.Error("Empty charts should be rendered with 'No data' label")
+	}
+
+	if strings.Contains(buffer.String(), "<h2>Stages</h2>") {
+		t.Error("HTML report should not contain stages of a run without a load profile")
+	}
+
+	if strings.Contains(buffer.String(), "mean think time") {
+		t.Error("HTML report should not contain think time of a run without pauses")
+	}
+
+	if strings.Contains(buffer.String(), "connection reuse") {
+		t.Error("HTML report should not contain connection reuse of a run without connections")
+	}
+
+	if strings.Contains(buffer.String(), "Execution was interrupted") {
+		t.Error("HTML report should not mark a run which was not interrupted")
+	}
+}
+
+func TestNiceCeil(t *testing.T) {
+	var expectations = map[float64]float64{0: 1, 0.7: 1, 3: 5, 11: 20, 240: 250, 1000: 1000}
+
+	for given, expected := range expectations {
+		if actual := niceCeil(given); actual != expected {
+			t.Errorf("Unexpected nice ceil for %v, actual: %v, expected: %v", given, actual, expected)
+		}
+	}
+}
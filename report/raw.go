@@ -0,0 +1,153 @@
+package report
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/vkrava4/curlson/stats"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RawColumns is a list of columns of a raw results CSV file
+var RawColumns = []string{"timestamp", "thread_id", "url", "status", "bytes", "latency_ms", "error_class", "error", "stage", "connection"}
+
+// RawRecord is a machine-readable representation of a single request result
+type RawRecord struct {
+	Timestamp  time.Time `json:"timestamp"`
+	ThreadID   int       `json:"thread_id"`
+	URL        string    `json:"url"`
+	Status     int       `json:"status"`
+	Bytes      int64     `json:"bytes"`
+	LatencyMs  float64   `json:"latency_ms"`
+	ErrorClass string    `json:"error_class,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	Stage      int       `json:"stage,omitempty"`
+	Connection string    `json:"connection,omitempty"`
+}
+
+// NewRawRecord creates RawRecord from a given request result
+func NewRawRecord(result stats.Result) RawRecord {
+	var record = RawRecord{
+		Timestamp:  result.StartTime,
+		ThreadID:   result.ThreadID,
+		URL:        result.URL,
+		Status:     result.StatusCode,
+		Bytes:      result.Bytes,
+		LatencyMs:  Milliseconds(result.Latency),
+		ErrorClass: string(result.ErrorClass),
+		Stage:      result.Stage,
+		Connection: string(result.Connection),
+	}
+
+	if result.Err != nil {
+		record.Error = result.Err.Error()
+	}
+
+	return record
+}
+
+// RawWriter streams raw results of every performed request into a file. It implements stats.ResultWriter
+// and is safe for concurrent use
+type RawWriter struct {
+	mutex  sync.Mutex
+	file   *os.File
+	buffer *bufio.Writer
+	format string
+
+	csvWriter   *csv.Writer
+	jsonEncoder *json.Encoder
+}
+
+// RawFormatForPath determines a raw results format by a file extension: FormatJSONL for '.jsonl', '.ndjson'
+// and '.json' files and FormatCSV otherwise
+func RawFormatForPath(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jsonl", ".json", ".ndjson":
+		return FormatJSONL
+	default:
+		return FormatCSV
+	}
+}
+
+// CreateRawWriter creates a file with a given path and RawWriter which writes results into it in a given format:
+// FormatCSV or FormatJSONL
+func CreateRawWriter(path string, format string) (*RawWriter, error) {
+	if format != FormatCSV && format != FormatJSONL {
+		return nil, fmt.Errorf("unsupported raw results format '%s'", format)
+	}
+
+	var file, errCreate = os.Create(path)
+	if errCreate != nil {
+		return nil, errCreate
+	}
+
+	var writer = &RawWriter{
+		file:   file,
+		buffer: bufio.NewWriter(file),
+		format: format,
+	}
+
+	if format == FormatCSV {
+		writer.csvWriter = csv.NewWriter(writer.buffer)
+		if errHeader := writer.csvWriter.Write(RawColumns); errHeader != nil {
+			_ = file.Close()
+			return nil, errHeader
+		}
+	} else {
+		writer.jsonEncoder = json.NewEncoder(writer.buffer)
+	}
+
+	return writer, nil
+}
+
+// WriteResult writes a single result as a CSV row or a JSON line
+func (w *RawWriter) WriteResult(result stats.Result) error {
+	var record = NewRawRecord(result)
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.csvWriter != nil {
+		return w.csvWriter.Write([]string{
+			record.Timestamp.Format(time.RFC3339Nano),
+			strconv.Itoa(record.ThreadID),
+			record.URL,
+			strconv.Itoa(record.Status),
+			strconv.FormatInt(record.Bytes, 10),
+			formatFloat(record.LatencyMs),
+			record.ErrorClass,
+			record.Error,
+			strconv.Itoa(record.Stage),
+			record.Connection,
+		})
+	}
+
+	return w.jsonEncoder.Encode(record)
+}
+
+// Close flushes buffered results and closes the underlying file
+func (w *RawWriter) Close() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.csvWriter != nil {
+		w.csvWriter.Flush()
+		if errFlush := w.csvWriter.Error(); errFlush != nil {
+			_ = w.file.Close()
+			return errFlush
+		}
+	}
+
+	if errFlush := w.buffer.Flush(); errFlush != nil {
+		_ = w.file.Close()
+		return errFlush
+	}
+
+	return w.file.Close()
+}
@@ -0,0 +1,134 @@
+package report
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/vkrava4/curlson/stats"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"time"
+)
+
+// ReadRawResults reads a raw results file previously written by RawWriter and passes every result to a given function.
+// A format is determined by a file extension, see RawFormatForPath
+func ReadRawResults(path string, consume func(result stats.Result)) error {
+	var file, errOpen = os.Open(path)
+	if errOpen != nil {
+		return errOpen
+	}
+	defer file.Close()
+
+	if RawFormatForPath(path) == FormatJSONL {
+		return readRawJSONL(file, consume)
+	}
+	return readRawCSV(file, consume)
+}
+
+func readRawJSONL(reader io.Reader, consume func(result stats.Result)) error {
+	var scanner = bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	var lineNumber = 0
+	for scanner.Scan() {
+		lineNumber++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var record RawRecord
+		if errDecode := json.Unmarshal(scanner.Bytes(), &record); errDecode != nil {
+			return fmt.Errorf("line %d: %s", lineNumber, errDecode.Error())
+		}
+		consume(record.Result())
+	}
+
+	return scanner.Err()
+}
+
+func readRawCSV(reader io.Reader, consume func(result stats.Result)) error {
+	var csvReader = csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+
+	var header, errHeader = csvReader.Read()
+	if errHeader != nil {
+		return errHeader
+	}
+	if header[0] != RawColumns[0] {
+		return errors.New("raw results CSV file should start with a header row")
+	}
+
+	for lineNumber := 2; ; lineNumber++ {
+		var row, errRead = csvReader.Read()
+		if errRead == io.EOF {
+			return nil
+		}
+		if errRead != nil {
+			return errRead
+		}
+
+		if len(row) != len(RawColumns) {
+			return fmt.Errorf("line %d: wrong number of fields %d", lineNumber, len(row))
+		}
+
+		var record, errParse = parseRawCSVRow(row)
+		if errParse != nil {
+			return fmt.Errorf("line %d: %s", lineNumber, errParse.Error())
+		}
+		consume(record.Result())
+	}
+}
+
+func parseRawCSVRow(row []string) (RawRecord, error) {
+	var record = RawRecord{URL: row[2], ErrorClass: row[6], Error: row[7], Connection: row[9]}
+	var errParse error
+
+	if record.Timestamp, errParse = time.Parse(time.RFC3339Nano, row[0]); errParse != nil {
+		return record, errParse
+	}
+	if record.ThreadID, errParse = strconv.Atoi(row[1]); errParse != nil {
+		return record, errParse
+	}
+	if record.Status, errParse = strconv.Atoi(row[3]); errParse != nil {
+		return record, errParse
+	}
+	if record.Bytes, errParse = strconv.ParseInt(row[4], 10, 64); errParse != nil {
+		return record, errParse
+	}
+	if record.LatencyMs, errParse = strconv.ParseFloat(row[5], 64); errParse != nil {
+		return record, errParse
+	}
+	if record.Stage, errParse = strconv.Atoi(row[8]); errParse != nil {
+		return record, errParse
+	}
+
+	return record, nil
+}
+
+// Result converts RawRecord back to stats.Result
+func (record RawRecord) Result() stats.Result {
+	var result = stats.Result{
+		ThreadID:   record.ThreadID,
+		StartTime:  record.Timestamp,
+		URL:        record.URL,
+		Latency:    time.Duration(math.Round(record.LatencyMs * float64(time.Millisecond))),
+		StatusCode: record.Status,
+		Bytes:      record.Bytes,
+		ErrorClass: stats.ErrorClass(record.ErrorClass),
+		Stage:      record.Stage,
+		Connection: stats.Connection(record.Connection),
+	}
+
+	if record.Error != "" {
+		result.Err = errors.New(record.Error)
+		if result.ErrorClass == "" {
+			result.ErrorClass = stats.ErrorClassOther
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,63 @@
+package report
+
+import (
+	"github.com/vkrava4/curlson/stats"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadRawResultsWrittenByRawWriter(t *testing.T) {
+	for _, givenFile := range []string{"raw_reader_test.csv", "raw_reader_test.jsonl"} {
+		var path, _ = filepath.Abs(givenFile)
+		var writer, _ = CreateRawWriter(path, RawFormatForPath(path))
+		for _, result := range givenResults() {
+			_ = writer.WriteResult(result)
+		}
+		_ = writer.Close()
+
+		var actualResults []stats.Result
+		var errRead = ReadRawResults(path, func(result stats.Result) {
+			actualResults = append(actualResults, result)
+		})
+		_ = os.Remove(path)
+
+		if errRead != nil {
+			t.Fatalf("An error is not expected for '%s': %v", givenFile, errRead)
+		}
+
+		var expectedResults = givenResults()
+		if len(actualResults) != len(expectedResults) {
+			t.Fatalf("Unexpected results for '%s': %v", givenFile, actualResults)
+		}
+
+		for i, actual := range actualResults {
+			var expected = expectedResults[i]
+			if !actual.StartTime.Equal(expected.StartTime) || actual.URL != expected.URL || actual.Latency != expected.Latency ||
+				actual.StatusCode != expected.StatusCode || actual.Bytes != expected.Bytes || actual.ErrorClass != expected.ErrorClass ||
+				actual.Stage != expected.Stage || actual.Connection != expected.Connection || (actual.Err == nil) != (expected.Err == nil) {
+				t.Errorf("Unexpected result for '%s', actual: %v, expected: %v", givenFile, actual, expected)
+			}
+		}
+	}
+}
+
+func TestReadRawResultsFromInvalidFile(t *testing.T) {
+	var path, _ = filepath.Abs("raw_reader_invalid.csv")
+	defer os.Remove(path)
+
+	for _, givenContent := range []string{
+		"timestamp,thread_id,url,status,bytes,latency_ms,error_class,error,stage,connection\nyesterday,0,url,200,1,1,,,0,new\n",
+		"timestamp,thread_id,url,status,bytes,latency_ms,error_class,error,stage\n2020-05-11T10:00:00Z,1,url,200,1,2.5,,,3\n",
+	} {
+		_ = ioutil.WriteFile(path, []byte(givenContent), 0666)
+		if errRead := ReadRawResults(path, func(result stats.Result) {}); errRead == nil {
+			t.Errorf("An error is expected for invalid raw results file:\n%s", givenContent)
+		}
+	}
+
+	if errRead := ReadRawResults("raw_reader_not_found.csv", func(result stats.Result) {}); errRead == nil {
+		t.Error("An error is expected for missing raw results file")
+	}
+}
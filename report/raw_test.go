@@ -0,0 +1,94 @@
+package report
+
+import (
+	"errors"
+	"github.com/vkrava4/curlson/stats"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func givenResults() []stats.Result {
+	var startTime = time.Date(2020, 5, 11, 10, 0, 0, 0, time.UTC)
+	return []stats.Result{
+		{ThreadID: 0, StartTime: startTime, URL: "http://localhost/a,b", Latency: 1500 * time.Microsecond, StatusCode: 200, Bytes: 42, Connection: stats.ConnectionReused},
+		{ThreadID: 1, StartTime: startTime, URL: "http://localhost/b", Latency: time.Second, Err: errors.New("i/o timeout"), ErrorClass: stats.ErrorClassTimeout, Stage: 2},
+	}
+}
+
+func TestRawFormatForPath(t *testing.T) {
+	var expectations = map[string]string{
+		"results.csv":   FormatCSV,
+		"results":       FormatCSV,
+		"results.jsonl": FormatJSONL,
+		"results.JSON":  FormatJSONL,
+	}
+
+	for path, expected := range expectations {
+		if actual := RawFormatForPath(path); actual != expected {
+			t.Errorf("Unexpected format for '%s', actual: '%s', expected: '%s'", path, actual, expected)
+		}
+	}
+}
+
+func TestRawWriterAsCSV(t *testing.T) {
+	var path, _ = filepath.Abs("raw_test.csv")
+	defer os.Remove(path)
+
+	var writer, errCreate = CreateRawWriter(path, FormatCSV)
+	if errCreate != nil {
+		t.Fatalf("An error is not expected: %v", errCreate)
+	}
+
+	for _, result := range givenResults() {
+		_ = writer.WriteResult(result)
+	}
+	_ = writer.Close()
+
+	var content, _ = ioutil.ReadFile(path)
+	var expected = strings.Join([]string{
+		"timestamp,thread_id,url,status,bytes,latency_ms,error_class,error,stage,connection",
+		"2020-05-11T10:00:00Z,0,\"http://localhost/a,b\",200,42,1.5,,,0,reused",
+		"2020-05-11T10:00:00Z,1,http://localhost/b,0,0,1000,timeout,i/o timeout,2,",
+		"",
+	}, "\n")
+
+	if string(content) != expected {
+		t.Errorf("Unexpected raw CSV content, actual:\n%s\nexpected:\n%s", content, expected)
+	}
+}
+
+func TestRawWriterAsJSONL(t *testing.T) {
+	var path, _ = filepath.Abs("raw_test.jsonl")
+	defer os.Remove(path)
+
+	var writer, errCreate = CreateRawWriter(path, FormatJSONL)
+	if errCreate != nil {
+		t.Fatalf("An error is not expected: %v", errCreate)
+	}
+
+	for _, result := range givenResults() {
+		_ = writer.WriteResult(result)
+	}
+	_ = writer.Close()
+
+	var content, _ = ioutil.ReadFile(path)
+	var expected = strings.Join([]string{
+		`{"timestamp":"2020-05-11T10:00:00Z","thread_id":0,"url":"http://localhost/a,b","status":200,"bytes":42,"latency_ms":1.5,"connection":"reused"}`,
+		`{"timestamp":"2020-05-11T10:00:00Z","thread_id":1,"url":"http://localhost/b","status":0,"bytes":0,"latency_ms":1000,"error_class":"timeout","error":"i/o timeout","stage":2}`,
+		"",
+	}, "\n")
+
+	if string(content) != expected {
+		t.Errorf("Unexpected raw JSONL content, actual:\n%s\nexpected:\n%s", content, expected)
+	}
+}
+
+func TestCreateRawWriterWithUnsupportedFormat(t *testing.T) {
+	if _, errCreate := CreateRawWriter("raw_test.xml", "xml"); errCreate == nil {
+		t.Error("An error is expected for unsupported format")
+	}
+}
@@ -0,0 +1,286 @@
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/vkrava4/curlson/stats"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	FormatJSON  = "json"
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+)
+
+// Run holds the information about an execution which is written alongside its statistics
+type Run struct {
+	Command       string
+	URL           string
+	Configuration map[string]string
+}
+
+// SummaryDocument is a machine-readable representation of an execution summary
+type SummaryDocument struct {
+	Command       string              `json:"command"`
+	URL           string              `json:"url"`
+	Configuration map[string]string   `json:"configuration"`
+	StartTime     time.Time           `json:"start_time"`
+	EndTime       time.Time           `json:"end_time"`
+	DurationMs    float64             `json:"duration_ms"`
+	Requests      int64               `json:"requests"`
+	Throughput    float64             `json:"throughput_rps"`
+	Latency       LatencyDocument     `json:"latency_ms"`
+	StatusCodes   map[string]int64    `json:"status_codes"`
+	Classes       []ClassDocument     `json:"classes"`
+	Errors        map[string]int64    `json:"errors"`
+	Late          int64               `json:"late_dispatches"`
+	Stages        []StageDocument     `json:"stages,omitempty"`
+	ThinkTime     *ThinkTimeDocument  `json:"think_time,omitempty"`
+	Connections   *ConnectionDocument `json:"connections,omitempty"`
+	Interrupted   bool                `json:"interrupted"`
+}
+
+// LatencyDocument is a machine-readable representation of stats.LatencySummary with values in milliseconds
+type LatencyDocument struct {
+	Min    float64 `json:"min"`
+	Mean   float64 `json:"mean"`
+	Max    float64 `json:"max"`
+	StdDev float64 `json:"stddev"`
+	P50    float64 `json:"p50"`
+	P90    float64 `json:"p90"`
+	P95    float64 `json:"p95"`
+	P99    float64 `json:"p99"`
+	P999   float64 `json:"p99_9"`
+}
+
+// ClassDocument is a machine-readable representation of stats.ClassSummary
+type ClassDocument struct {
+	Class   string          `json:"class"`
+	Count   int64           `json:"count"`
+	Latency LatencyDocument `json:"latency_ms"`
+}
+
+// StageDocument is a machine-readable representation of stats.StageSummary
+type StageDocument struct {
+	Stage      int             `json:"stage"`
+	Requests   int64           `json:"requests"`
+	Failures   int64           `json:"failures"`
+	DurationMs float64         `json:"duration_ms"`
+	Throughput float64         `json:"throughput_rps"`
+	Latency    LatencyDocument `json:"latency_ms"`
+}
+
+// ThinkTimeDocument is a machine-readable representation of stats.ThinkTimeSummary
+type ThinkTimeDocument struct {
+	Pauses       int64           `json:"pauses"`
+	TotalMs      float64         `json:"total_ms"`
+	Distribution LatencyDocument `json:"distribution_ms"`
+}
+
+// ConnectionDocument is a machine-readable representation of stats.ConnectionSummary
+type ConnectionDocument struct {
+	New        int64   `json:"new"`
+	Reused     int64   `json:"reused"`
+	ReuseRatio float64 `json:"reuse_ratio"`
+}
+
+// NewSummaryDocument creates SummaryDocument for a given run and its summary
+func NewSummaryDocument(run Run, summary *stats.Summary) *SummaryDocument {
+	var document = &SummaryDocument{
+		Command:       run.Command,
+		URL:           run.URL,
+		Configuration: run.Configuration,
+		StartTime:     summary.StartTime,
+		EndTime:       summary.EndTime,
+		DurationMs:    Milliseconds(summary.Duration),
+		Requests:      summary.Requests,
+		Throughput:    summary.Throughput,
+		Latency:       newLatencyDocument(summary.Latency),
+		StatusCodes:   make(map[string]int64),
+		Classes:       []ClassDocument{},
+		Errors:        make(map[string]int64),
+		Late:          summary.LateDispatches,
+		Interrupted:   summary.Interrupted,
+	}
+
+	for _, statusCode := range summary.StatusCodes {
+		document.StatusCodes[strconv.Itoa(statusCode.StatusCode)] = statusCode.Count
+	}
+
+	for _, class := range summary.Classes {
+		document.Classes = append(document.Classes, ClassDocument{Class: class.Class, Count: class.Count, Latency: newLatencyDocument(class.Latency)})
+	}
+
+	for _, errorCount := range summary.Errors {
+		document.Errors[string(errorCount.Class)] = errorCount.Count
+	}
+
+	if summary.ThinkTime.Pauses > 0 {
+		document.ThinkTime = &ThinkTimeDocument{
+			Pauses:       summary.ThinkTime.Pauses,
+			TotalMs:      Milliseconds(summary.ThinkTime.Total),
+			Distribution: newLatencyDocument(summary.ThinkTime.Distribution),
+		}
+	}
+
+	if connections := summary.Connections; connections.New+connections.Reused > 0 {
+		document.Connections = &ConnectionDocument{New: connections.New, Reused: connections.Reused, ReuseRatio: connections.ReuseRatio()}
+	}
+
+	for _, stage := range summary.Stages {
+		document.Stages = append(document.Stages, StageDocument{
+			Stage:      stage.Stage,
+			Requests:   stage.Requests,
+			Failures:   stage.Failures,
+			DurationMs: Milliseconds(stage.Duration),
+			Throughput: stage.Throughput,
+			Latency:    newLatencyDocument(stage.Latency),
+		})
+	}
+
+	return document
+}
+
+// WriteSummary writes a summary of a given run to w in a given format: FormatJSON or FormatCSV.
+// The CSV format is a flat list of 'metric,value' rows
+func WriteSummary(w io.Writer, format string, run Run, summary *stats.Summary) error {
+	var document = NewSummaryDocument(run, summary)
+
+	switch format {
+	case FormatJSON:
+		var encoder = json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(document)
+
+	case FormatCSV:
+		return writeSummaryCSV(w, document)
+
+	default:
+		return fmt.Errorf("unsupported summary format '%s'", format)
+	}
+}
+
+func writeSummaryCSV(w io.Writer, document *SummaryDocument) error {
+	var writer = csv.NewWriter(w)
+	var rows = [][]string{
+		{"metric", "value"},
+		{"command", document.Command},
+		{"url", document.URL},
+		{"start_time", document.StartTime.Format(time.RFC3339Nano)},
+		{"end_time", document.EndTime.Format(time.RFC3339Nano)},
+		{"duration_ms", formatFloat(document.DurationMs)},
+		{"requests", strconv.FormatInt(document.Requests, 10)},
+		{"throughput_rps", formatFloat(document.Throughput)},
+		{"late_dispatches", strconv.FormatInt(document.Late, 10)},
+		{"interrupted", strconv.FormatBool(document.Interrupted)},
+	}
+
+	rows = append(rows, latencyRows("latency_ms", document.Latency)...)
+	if document.ThinkTime != nil {
+		rows = append(rows,
+			[]string{"think_time.pauses", strconv.FormatInt(document.ThinkTime.Pauses, 10)},
+			[]string{"think_time.total_ms", formatFloat(document.ThinkTime.TotalMs)})
+		rows = append(rows, latencyRows("think_time.distribution_ms", document.ThinkTime.Distribution)...)
+	}
+
+	if document.Connections != nil {
+		rows = append(rows,
+			[]string{"connections.new", strconv.FormatInt(document.Connections.New, 10)},
+			[]string{"connections.reused", strconv.FormatInt(document.Connections.Reused, 10)},
+			[]string{"connections.reuse_ratio", formatFloat(document.Connections.ReuseRatio)})
+	}
+
+	for _, class := range document.Classes {
+		rows = append(rows, []string{"classes." + class.Class + ".count", strconv.FormatInt(class.Count, 10)})
+		rows = append(rows, latencyRows("classes."+class.Class+".latency_ms", class.Latency)...)
+	}
+
+	for _, stage := range document.Stages {
+		var prefix = "stages." + strconv.Itoa(stage.Stage)
+		rows = append(rows,
+			[]string{prefix + ".requests", strconv.FormatInt(stage.Requests, 10)},
+			[]string{prefix + ".failures", strconv.FormatInt(stage.Failures, 10)},
+			[]string{prefix + ".duration_ms", formatFloat(stage.DurationMs)},
+			[]string{prefix + ".throughput_rps", formatFloat(stage.Throughput)})
+		rows = append(rows, latencyRows(prefix+".latency_ms", stage.Latency)...)
+	}
+
+	rows = append(rows, sortedCountRows("status_codes", document.StatusCodes)...)
+	rows = append(rows, sortedCountRows("errors", document.Errors)...)
+	rows = append(rows, sortedValueRows("configuration", document.Configuration)...)
+
+	if errWrite := writer.WriteAll(rows); errWrite != nil {
+		return errWrite
+	}
+	return writer.Error()
+}
+
+func latencyRows(prefix string, latency LatencyDocument) [][]string {
+	return [][]string{
+		{prefix + ".min", formatFloat(latency.Min)},
+		{prefix + ".mean", formatFloat(latency.Mean)},
+		{prefix + ".max", formatFloat(latency.Max)},
+		{prefix + ".stddev", formatFloat(latency.StdDev)},
+		{prefix + ".p50", formatFloat(latency.P50)},
+		{prefix + ".p90", formatFloat(latency.P90)},
+		{prefix + ".p95", formatFloat(latency.P95)},
+		{prefix + ".p99", formatFloat(latency.P99)},
+		{prefix + ".p99_9", formatFloat(latency.P999)},
+	}
+}
+
+func sortedCountRows(prefix string, counts map[string]int64) [][]string {
+	var keys = make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var rows = make([][]string, 0, len(keys))
+	for _, key := range keys {
+		rows = append(rows, []string{prefix + "." + key, strconv.FormatInt(counts[key], 10)})
+	}
+	return rows
+}
+
+func sortedValueRows(prefix string, values map[string]string) [][]string {
+	var keys = make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var rows = make([][]string, 0, len(keys))
+	for _, key := range keys {
+		rows = append(rows, []string{prefix + "." + key, values[key]})
+	}
+	return rows
+}
+
+func newLatencyDocument(latency stats.LatencySummary) LatencyDocument {
+	return LatencyDocument{
+		Min:    Milliseconds(latency.Min),
+		Mean:   Milliseconds(latency.Mean),
+		Max:    Milliseconds(latency.Max),
+		StdDev: Milliseconds(latency.StdDev),
+		P50:    Milliseconds(latency.P50),
+		P90:    Milliseconds(latency.P90),
+		P95:    Milliseconds(latency.P95),
+		P99:    Milliseconds(latency.P99),
+		P999:   Milliseconds(latency.P999),
+	}
+}
+
+// Milliseconds converts a duration to a fractional number of milliseconds rounded to microseconds
+func Milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,82 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/vkrava4/curlson/stats"
+	"strings"
+	"testing"
+	"time"
+)
+
+func givenSummary() *stats.Summary {
+	var startTime = time.Date(2020, 5, 11, 10, 0, 0, 0, time.UTC)
+	return &stats.Summary{
+		StartTime:   startTime,
+		EndTime:     startTime.Add(2 * time.Second),
+		Duration:    2 * time.Second,
+		Requests:    3,
+		Throughput:  1.5,
+		Latency:     stats.LatencySummary{Min: 1500 * time.Microsecond, P999: 20 * time.Millisecond},
+		StatusCodes: []stats.StatusCodeCount{{StatusCode: 200, Count: 2}},
+		Classes:     []stats.ClassSummary{{Class: "2xx", Count: 2}, {Class: stats.ClassTransportErrors, Count: 1}},
+		Errors:      []stats.ErrorCount{{Class: stats.ErrorClassTimeout, Count: 1}},
+
+		LateDispatches: 4,
+		ThinkTime:      stats.ThinkTimeSummary{Pauses: 2, Total: time.Second, Distribution: stats.LatencySummary{Mean: 500 * time.Millisecond}},
+		Stages:         []stats.StageSummary{{Stage: 1, Requests: 3, Failures: 1, Duration: time.Second, Throughput: 3}},
+		Connections:    stats.ConnectionSummary{New: 1, Reused: 3},
+		Interrupted:    true,
+	}
+}
+
+func givenRun() Run {
+	return Run{Command: "get", URL: "http://localhost:8080", Configuration: map[string]string{"threads": "2"}}
+}
+
+func TestWriteSummaryAsJSON(t *testing.T) {
+	var buffer = &bytes.Buffer{}
+
+	if errWrite := WriteSummary(buffer, FormatJSON, givenRun(), givenSummary()); errWrite != nil {
+		t.Fatalf("An error is not expected: %v", errWrite)
+	}
+
+	var document SummaryDocument
+	if errDecode := json.Unmarshal(buffer.Bytes(), &document); errDecode != nil {
+		t.Fatalf("Summary should be a valid JSON document: %v", errDecode)
+	}
+
+	if document.Command != "get" || document.Requests != 3 || document.DurationMs != 2000 || document.Latency.Min != 1.5 ||
+		document.Latency.P999 != 20 || document.StatusCodes["200"] != 2 || document.Errors["timeout"] != 1 ||
+		len(document.Classes) != 2 || document.Configuration["threads"] != "2" || document.Late != 4 ||
+		len(document.Stages) != 1 || document.Stages[0].Failures != 1 || document.Stages[0].DurationMs != 1000 ||
+		document.ThinkTime == nil || document.ThinkTime.Pauses != 2 || document.ThinkTime.Distribution.Mean != 500 ||
+		document.Connections == nil || document.Connections.Reused != 3 || document.Connections.ReuseRatio != 0.75 || !document.Interrupted {
+		t.Errorf("Unexpected summary document: %+v", document)
+	}
+}
+
+func TestWriteSummaryAsCSV(t *testing.T) {
+	var buffer = &bytes.Buffer{}
+
+	if errWrite := WriteSummary(buffer, FormatCSV, givenRun(), givenSummary()); errWrite != nil {
+		t.Fatalf("An error is not expected: %v", errWrite)
+	}
+
+	var output = buffer.String()
+	for _, expected := range []string{"metric,value\n", "requests,3\n", "latency_ms.min,1.5\n", "status_codes.200,2\n",
+		"errors.timeout,1\n", "classes.errors.count,1\n", "configuration.threads,2\n", "late_dispatches,4\n",
+		"stages.1.requests,3\n", "stages.1.throughput_rps,3\n", "stages.1.latency_ms.p50,0\n",
+		"think_time.pauses,2\n", "think_time.total_ms,1000\n", "think_time.distribution_ms.mean,500\n",
+		"connections.new,1\n", "connections.reused,3\n", "connections.reuse_ratio,0.75\n", "interrupted,true\n"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Summary CSV should contain '%s', actual output: %s", expected, output)
+		}
+	}
+}
+
+func TestWriteSummaryWithUnsupportedFormat(t *testing.T) {
+	if errWrite := WriteSummary(&bytes.Buffer{}, "xml", givenRun(), givenSummary()); errWrite == nil {
+		t.Error("An error is expected for unsupported format")
+	}
+}
@@ -0,0 +1,237 @@
+package report
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	chartWidth        = 860
+	chartHeight       = 280
+	chartMarginLeft   = 64
+	chartMarginRight  = 20
+	chartMarginTop    = 28
+	chartMarginBottom = 40
+	chartTicks        = 5
+)
+
+var chartPalette = []string{"#1e88e5", "#43a047", "#fb8c00", "#e53935", "#8e24aa", "#00acc1", "#6d4c41", "#546e7a", "#c0ca33", "#d81b60"}
+
+// chartSeries is a named sequence of values drawn as a single line
+type chartSeries struct {
+	Name   string
+	Color  string
+	Values []float64
+}
+
+// chartSlice is a named value drawn as a pie chart sector
+type chartSlice struct {
+	Label string
+	Color string
+	Value float64
+}
+
+// lineChartSVG renders series of values as an inline SVG line chart. Every value index is a point on X axis
+func lineChartSVG(series []chartSeries, xLabel string, yLabel string) template.HTML {
+	var pointsCount = 0
+	var maxValue = 0.0
+	for _, s := range series {
+		if len(s.Values) > pointsCount {
+			pointsCount = len(s.Values)
+		}
+		for _, value := range s.Values {
+			maxValue = math.Max(maxValue, value)
+		}
+	}
+
+	if pointsCount == 0 {
+		return emptyChartSVG()
+	}
+
+	var maxY = niceCeil(maxValue)
+	var builder = &strings.Builder{}
+	openChart(builder)
+	writeYAxis(builder, maxY, yLabel)
+	writeXAxis(builder, pointsCount, xLabel, strconv.Itoa, func(i int) float64 { return xPosition(i, pointsCount) })
+
+	for _, s := range series {
+		var path = &strings.Builder{}
+		for i, value := range s.Values {
+			var command = "L"
+			if i == 0 {
+				command = "M"
+			}
+			_, _ = fmt.Fprintf(path, "%s%.1f %.1f ", command, xPosition(i, pointsCount), yPosition(value, maxY))
+		}
+
+		_, _ = fmt.Fprintf(builder, `<path d="%s" fill="none" stroke="%s" stroke-width="1.5"><title>%s</title></path>`,
+			strings.TrimSpace(path.String()), s.Color, html.EscapeString(s.Name))
+	}
+
+	writeLegend(builder, series)
+	closeChart(builder)
+
+	return template.HTML(builder.String())
+}
+
+// barChartSVG renders values as an inline SVG bar chart with given labels of every bar
+func barChartSVG(values []float64, labels []string, xLabel string, yLabel string) template.HTML {
+	if len(values) == 0 {
+		return emptyChartSVG()
+	}
+
+	var maxValue = 0.0
+	for _, value := range values {
+		maxValue = math.Max(maxValue, value)
+	}
+
+	var maxY = niceCeil(maxValue)
+	var plotWidth = float64(chartWidth - chartMarginLeft - chartMarginRight)
+	var barWidth = plotWidth / float64(len(values))
+	var builder = &strings.Builder{}
+
+	openChart(builder)
+	writeYAxis(builder, maxY, yLabel)
+	writeXAxis(builder, len(values), xLabel, func(i int) string { return labels[i] },
+		func(i int) float64 { return chartMarginLeft + (float64(i)+0.5)*barWidth })
+
+	for i, value := range values {
+		var y = yPosition(value, maxY)
+		_, _ = fmt.Fprintf(builder, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"><title>%s: %s</title></rect>`,
+			chartMarginLeft+float64(i)*barWidth+1, y, math.Max(barWidth-2, 1), float64(chartHeight-chartMarginBottom)-y,
+			chartPalette[0], html.EscapeString(labels[i]), formatFloat(value))
+	}
+
+	closeChart(builder)
+	return template.HTML(builder.String())
+}
+
+// pieChartSVG renders slices as an inline SVG pie chart with a legend
+func pieChartSVG(slices []chartSlice) template.HTML {
+	var total = 0.0
+	for _, slice := range slices {
+		total += slice.Value
+	}
+
+	if total <= 0 {
+		return emptyChartSVG()
+	}
+
+	var centerX, centerY, radius = 140.0, float64(chartHeight) / 2, 110.0
+	var builder = &strings.Builder{}
+	openChart(builder)
+
+	var angle = -math.Pi / 2
+	for i, slice := range slices {
+		var share = slice.Value / total
+		var title = fmt.Sprintf("%s: %s (%.2f%%)", slice.Label, formatFloat(slice.Value), share*100)
+
+		if share >= 1 {
+			_, _ = fmt.Fprintf(builder, `<circle cx="%.1f" cy="%.1f" r="%.1f" fill="%s"><title>%s</title></circle>`,
+				centerX, centerY, radius, slice.Color, html.EscapeString(title))
+		} else if share > 0 {
+			var endAngle = angle + share*2*math.Pi
+			var largeArc = 0
+			if share > 0.5 {
+				largeArc = 1
+			}
+
+			_, _ = fmt.Fprintf(builder, `<path d="M%.1f %.1f L%.1f %.1f A%.1f %.1f 0 %d 1 %.1f %.1f Z" fill="%s" stroke="#fff"><title>%s</title></path>`,
+				centerX, centerY, centerX+radius*math.Cos(angle), centerY+radius*math.Sin(angle), radius, radius, largeArc,
+				centerX+radius*math.Cos(endAngle), centerY+radius*math.Sin(endAngle), slice.Color, html.EscapeString(title))
+			angle = endAngle
+		}
+
+		_, _ = fmt.Fprintf(builder, `<rect x="300" y="%d" width="12" height="12" fill="%s"/><text x="318" y="%d">%s</text>`,
+			chartMarginTop+i*20, slice.Color, chartMarginTop+i*20+11, html.EscapeString(title))
+	}
+
+	closeChart(builder)
+	return template.HTML(builder.String())
+}
+
+func openChart(builder *strings.Builder) {
+	_, _ = fmt.Fprintf(builder, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" class="chart">`, chartWidth, chartHeight)
+}
+
+func closeChart(builder *strings.Builder) {
+	builder.WriteString("</svg>")
+}
+
+func emptyChartSVG() template.HTML {
+	var builder = &strings.Builder{}
+	openChart(builder)
+	_, _ = fmt.Fprintf(builder, `<text x="%d" y="%d" text-anchor="middle" class="muted">No data</text>`, chartWidth/2, chartHeight/2)
+	closeChart(builder)
+	return template.HTML(builder.String())
+}
+
+func writeYAxis(builder *strings.Builder, maxY float64, label string) {
+	for i := 0; i <= chartTicks; i++ {
+		var value = maxY * float64(i) / chartTicks
+		var y = yPosition(value, maxY)
+		_, _ = fmt.Fprintf(builder, `<line x1="%d" y1="%.1f" x2="%d" y2="%.1f" class="grid"/>`, chartMarginLeft, y, chartWidth-chartMarginRight, y)
+		_, _ = fmt.Fprintf(builder, `<text x="%d" y="%.1f" text-anchor="end" class="tick">%s</text>`, chartMarginLeft-6, y+4, formatFloat(roundTick(value)))
+	}
+
+	_, _ = fmt.Fprintf(builder, `<text x="%d" y="%d" class="label">%s</text>`, chartMarginLeft, chartMarginTop-12, html.EscapeString(label))
+}
+
+func writeXAxis(builder *strings.Builder, pointsCount int, label string, tickLabel func(i int) string, tickPosition func(i int) float64) {
+	var baseline = chartHeight - chartMarginBottom
+	_, _ = fmt.Fprintf(builder, `<line x1="%d" y1="%d" x2="%d" y2="%d" class="axis"/>`, chartMarginLeft, baseline, chartWidth-chartMarginRight, baseline)
+
+	var step = int(math.Ceil(float64(pointsCount) / 10))
+	for i := 0; i < pointsCount; i += step {
+		_, _ = fmt.Fprintf(builder, `<text x="%.1f" y="%d" text-anchor="middle" class="tick">%s</text>`,
+			tickPosition(i), baseline+16, html.EscapeString(tickLabel(i)))
+	}
+
+	_, _ = fmt.Fprintf(builder, `<text x="%d" y="%d" text-anchor="end" class="label">%s</text>`,
+		chartWidth-chartMarginRight, chartHeight-4, html.EscapeString(label))
+}
+
+func writeLegend(builder *strings.Builder, series []chartSeries) {
+	var x = chartWidth - chartMarginRight
+	for i := len(series) - 1; i >= 0; i-- {
+		x -= 12 + 7*len(series[i].Name) + 16
+		_, _ = fmt.Fprintf(builder, `<rect x="%d" y="%d" width="10" height="10" fill="%s"/><text x="%d" y="%d" class="tick">%s</text>`,
+			x, chartMarginTop-22, series[i].Color, x+14, chartMarginTop-13, html.EscapeString(series[i].Name))
+	}
+}
+
+func xPosition(index int, pointsCount int) float64 {
+	var plotWidth = float64(chartWidth - chartMarginLeft - chartMarginRight)
+	if pointsCount < 2 {
+		return chartMarginLeft + plotWidth/2
+	}
+	return chartMarginLeft + plotWidth*float64(index)/float64(pointsCount-1)
+}
+
+func yPosition(value float64, maxY float64) float64 {
+	var plotHeight = float64(chartHeight - chartMarginTop - chartMarginBottom)
+	return chartMarginTop + plotHeight*(1-value/maxY)
+}
+
+// niceCeil rounds a value up to 1, 2, 2.5 or 5 multiplied by a power of ten
+func niceCeil(value float64) float64 {
+	if value <= 0 {
+		return 1
+	}
+
+	var magnitude = math.Pow(10, math.Floor(math.Log10(value)))
+	for _, step := range []float64{1, 2, 2.5, 5, 10} {
+		if value <= step*magnitude {
+			return step * magnitude
+		}
+	}
+	return 10 * magnitude
+}
+
+func roundTick(value float64) float64 {
+	return math.Round(value*1000) / 1000
+}
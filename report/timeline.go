@@ -0,0 +1,105 @@
+package report
+
+import (
+	"github.com/vkrava4/curlson/stats"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Timeline aggregates request results into one second intervals to describe how an execution evolved over time.
+// It implements stats.ResultWriter and is safe for concurrent use
+type Timeline struct {
+	mutex   sync.Mutex
+	buckets map[int64]*timelineBucket
+	latency *stats.Histogram
+}
+
+type timelineBucket struct {
+	latency  *stats.Histogram
+	failures int64
+}
+
+// TimelinePoint holds aggregated results of requests started within a single second of an execution
+type TimelinePoint struct {
+	Second   int
+	Requests int64
+	Failures int64
+	P50      time.Duration
+	P95      time.Duration
+	P99      time.Duration
+	Max      time.Duration
+}
+
+// NewTimeline creates an empty Timeline
+func NewTimeline() *Timeline {
+	return &Timeline{
+		buckets: make(map[int64]*timelineBucket),
+		latency: stats.NewHistogram(),
+	}
+}
+
+// WriteResult adds a result to an interval of its start time
+func (t *Timeline) WriteResult(result stats.Result) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	var second = result.StartTime.Unix()
+	var bucket, exists = t.buckets[second]
+	if !exists {
+		bucket = &timelineBucket{latency: stats.NewHistogram()}
+		t.buckets[second] = bucket
+	}
+
+	bucket.latency.Record(result.Latency)
+	// failures are counted the same way as in stats.Summary, so the chart agrees with the error rate
+	if result.ErrorClass != "" || result.Err != nil || result.StatusCode >= 400 {
+		bucket.failures++
+	}
+
+	t.latency.Record(result.Latency)
+	return nil
+}
+
+// Points returns timeline intervals ordered by time. Seconds without any started request are included with zero values
+func (t *Timeline) Points() []TimelinePoint {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if len(t.buckets) == 0 {
+		return nil
+	}
+
+	var seconds = make([]int64, 0, len(t.buckets))
+	for second := range t.buckets {
+		seconds = append(seconds, second)
+	}
+	sort.Slice(seconds, func(i, j int) bool { return seconds[i] < seconds[j] })
+
+	var firstSecond = seconds[0]
+	var points = make([]TimelinePoint, seconds[len(seconds)-1]-firstSecond+1)
+	for i := range points {
+		points[i].Second = i
+	}
+
+	for _, second := range seconds {
+		var bucket = t.buckets[second]
+		var point = &points[second-firstSecond]
+		point.Requests = bucket.latency.Count()
+		point.Failures = bucket.failures
+		point.P50 = bucket.latency.ValueAtPercentile(50)
+		point.P95 = bucket.latency.ValueAtPercentile(95)
+		point.P99 = bucket.latency.ValueAtPercentile(99)
+		point.Max = bucket.latency.Max()
+	}
+
+	return points
+}
+
+// Distribution returns a latency distribution of all results split into a given number of bins
+func (t *Timeline) Distribution(binsCount int) []stats.DistributionBin {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return t.latency.Distribution(binsCount)
+}
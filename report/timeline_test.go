@@ -0,0 +1,38 @@
+package report
+
+import (
+	"github.com/vkrava4/curlson/stats"
+	"testing"
+	"time"
+)
+
+func TestTimeline_Points(t *testing.T) {
+	var timeline = NewTimeline()
+	var startTime = time.Date(2020, 5, 11, 10, 0, 0, 0, time.UTC)
+
+	_ = timeline.WriteResult(stats.Result{StartTime: startTime, Latency: 10 * time.Millisecond, StatusCode: 200})
+	_ = timeline.WriteResult(stats.Result{StartTime: startTime.Add(500 * time.Millisecond), Latency: 30 * time.Millisecond, StatusCode: 200})
+	_ = timeline.WriteResult(stats.Result{StartTime: startTime.Add(700 * time.Millisecond), Latency: 20 * time.Millisecond, StatusCode: 503})
+	_ = timeline.WriteResult(stats.Result{StartTime: startTime.Add(2 * time.Second), Latency: time.Second, ErrorClass: stats.ErrorClassTimeout})
+
+	var points = timeline.Points()
+
+	if len(points) != 3 {
+		t.Fatalf("Timeline should include empty seconds, actual points: %v", points)
+	}
+
+	if points[0].Requests != 3 || points[0].Failures != 1 || points[0].Max != 30*time.Millisecond || points[1].Requests != 0 ||
+		points[2].Requests != 1 || points[2].Failures != 1 || points[2].Second != 2 {
+		t.Errorf("Unexpected timeline points: %v", points)
+	}
+
+	if bins := timeline.Distribution(5); len(bins) != 5 {
+		t.Errorf("Unexpected distribution: %v", bins)
+	}
+}
+
+func TestTimeline_PointsForEmptyTimeline(t *testing.T) {
+	if points := NewTimeline().Points(); points != nil {
+		t.Errorf("Empty timeline should not have points: %v", points)
+	}
+}
@@ -0,0 +1,79 @@
+package stats
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"syscall"
+)
+
+// ErrorClass describes a cause of a transport failure, i.e. a request which did not receive any HTTP response
+type ErrorClass string
+
+const (
+	ErrorClassDNS               ErrorClass = "dns"
+	ErrorClassConnectionRefused ErrorClass = "connection refused"
+	ErrorClassTimeout           ErrorClass = "timeout"
+	ErrorClassTLS               ErrorClass = "tls"
+	ErrorClassConnectionReset   ErrorClass = "connection reset"
+	ErrorClassOther             ErrorClass = "other"
+
+	// Timeouts of particular phases of a request which are classified separately from other timeouts
+	ErrorClassConnectTimeout        ErrorClass = "connect timeout"
+	ErrorClassTLSTimeout            ErrorClass = "tls timeout"
+	ErrorClassResponseHeaderTimeout ErrorClass = "response header timeout"
+	ErrorClassMaxTime               ErrorClass = "max time"
+)
+
+// Messages of errors of net/http package which have no exported types
+const (
+	tlsHandshakeTimeoutMessage   = "TLS handshake timeout"
+	responseHeaderTimeoutMessage = "timeout awaiting response headers"
+)
+
+// ClassifyError determines an ErrorClass of a given transport error
+func ClassifyError(err error) ErrorClass {
+	var dnsErr *net.DNSError
+	var opErr *net.OpError
+	var netErr net.Error
+	var recordHeaderErr tls.RecordHeaderError
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var certificateInvalidErr x509.CertificateInvalidError
+
+	switch {
+	case err == nil:
+		return ""
+
+	case errors.As(err, &dnsErr):
+		return ErrorClassDNS
+
+	case errors.As(err, &opErr) && opErr.Op == "dial" && opErr.Timeout():
+		return ErrorClassConnectTimeout
+
+	case strings.Contains(err.Error(), tlsHandshakeTimeoutMessage):
+		return ErrorClassTLSTimeout
+
+	case strings.Contains(err.Error(), responseHeaderTimeoutMessage):
+		return ErrorClassResponseHeaderTimeout
+
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return ErrorClassTimeout
+
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return ErrorClassConnectionRefused
+
+	case errors.As(err, &recordHeaderErr), errors.As(err, &unknownAuthorityErr), errors.As(err, &hostnameErr),
+		errors.As(err, &certificateInvalidErr), strings.Contains(err.Error(), "tls: "):
+		return ErrorClassTLS
+
+	case errors.Is(err, syscall.ECONNRESET), errors.Is(err, syscall.EPIPE), errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return ErrorClassConnectionReset
+
+	default:
+		return ErrorClassOther
+	}
+}
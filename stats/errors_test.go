@@ -0,0 +1,48 @@
+package stats
+
+import (
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"syscall"
+	"testing"
+)
+
+type timeoutError struct{}
+
+func (e timeoutError) Error() string   { return "i/o timeout" }
+func (e timeoutError) Timeout() bool   { return true }
+func (e timeoutError) Temporary() bool { return true }
+
+func TestClassifyError(t *testing.T) {
+	var wrap = func(err error) error {
+		return &url.Error{Op: "Get", URL: "http://localhost", Err: err}
+	}
+
+	var expectations = []struct {
+		err      error
+		expected ErrorClass
+	}{
+		{nil, ""},
+		{wrap(&net.DNSError{Err: "no such host", Name: "unknown.local"}), ErrorClassDNS},
+		{wrap(&net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}), ErrorClassConnectionRefused},
+		{wrap(&net.OpError{Op: "read", Err: timeoutError{}}), ErrorClassTimeout},
+		{wrap(&net.OpError{Op: "dial", Err: timeoutError{}}), ErrorClassConnectTimeout},
+		{wrap(errors.New("net/http: TLS handshake timeout")), ErrorClassTLSTimeout},
+		{wrap(errors.New("net/http: timeout awaiting response headers")), ErrorClassResponseHeaderTimeout},
+		{wrap(x509.UnknownAuthorityError{}), ErrorClassTLS},
+		{wrap(errors.New("remote error: tls: handshake failure")), ErrorClassTLS},
+		{wrap(&net.OpError{Op: "read", Err: os.NewSyscallError("read", syscall.ECONNRESET)}), ErrorClassConnectionReset},
+		{wrap(io.EOF), ErrorClassConnectionReset},
+		{wrap(errors.New("unsupported protocol scheme")), ErrorClassOther},
+	}
+
+	for _, expectation := range expectations {
+		if actual := ClassifyError(expectation.err); actual != expectation.expected {
+			t.Errorf("Unexpected error class for '%v', actual: '%s', expected: '%s'", expectation.err, actual, expectation.expected)
+		}
+	}
+}
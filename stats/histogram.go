@@ -0,0 +1,236 @@
+package stats
+
+import (
+	"math"
+	"math/bits"
+	"time"
+)
+
+const (
+	// A number of bits used for linear sub-buckets. 7 bits gives 128 sub-buckets of the first bucket and 64 sub-buckets
+	// of the upper half of every following bucket which keeps a relative recording error within 1/64 (about 1.56%)
+	subBucketBits      = 7
+	subBucketCount     = 1 << subBucketBits
+	subBucketHalfCount = subBucketCount / 2
+)
+
+// Histogram is an HDR-style (High Dynamic Range) histogram which records durations with microsecond
+// resolution into log-linear buckets. It allows calculating percentiles in a constant memory regardless
+// of the number of recorded values: counts grow only up to the highest recorded value. Histogram is not safe for concurrent use
+type Histogram struct {
+	counts     []int64
+	totalCount int64
+
+	min        time.Duration
+	max        time.Duration
+	sum        float64
+	sumSquares float64
+}
+
+// NewHistogram creates an empty Histogram
+func NewHistogram() *Histogram {
+	return &Histogram{
+		counts: make([]int64, subBucketCount),
+		min:    time.Duration(math.MaxInt64),
+	}
+}
+
+// Record adds a given duration to the histogram. Negative durations are recorded as zero
+func (h *Histogram) Record(value time.Duration) {
+	if value < 0 {
+		value = 0
+	}
+
+	var index = countsIndex(value.Microseconds())
+	h.ensureCountsLength(index + 1)
+	h.counts[index]++
+	h.totalCount++
+
+	if value < h.min {
+		h.min = value
+	}
+	if value > h.max {
+		h.max = value
+	}
+
+	var floatValue = float64(value)
+	h.sum += floatValue
+	h.sumSquares += floatValue * floatValue
+}
+
+// Merge adds all values recorded by other histogram to this one
+func (h *Histogram) Merge(other *Histogram) {
+	if other == nil || other.totalCount == 0 {
+		return
+	}
+
+	h.ensureCountsLength(len(other.counts))
+	for i, count := range other.counts {
+		h.counts[i] += count
+	}
+
+	h.totalCount += other.totalCount
+	h.sum += other.sum
+	h.sumSquares += other.sumSquares
+
+	if other.min < h.min {
+		h.min = other.min
+	}
+	if other.max > h.max {
+		h.max = other.max
+	}
+}
+
+// Count returns a total number of recorded values
+func (h *Histogram) Count() int64 {
+	return h.totalCount
+}
+
+// Min returns an exact minimum recorded value or zero if nothing was recorded
+func (h *Histogram) Min() time.Duration {
+	if h.totalCount == 0 {
+		return 0
+	}
+	return h.min
+}
+
+// Max returns an exact maximum recorded value
+func (h *Histogram) Max() time.Duration {
+	return h.max
+}
+
+// Sum returns an exact sum of recorded values
+func (h *Histogram) Sum() time.Duration {
+	return time.Duration(h.sum)
+}
+
+// Mean returns an exact arithmetic mean of recorded values
+func (h *Histogram) Mean() time.Duration {
+	if h.totalCount == 0 {
+		return 0
+	}
+	return time.Duration(h.sum / float64(h.totalCount))
+}
+
+// StdDev returns a population standard deviation of recorded values
+func (h *Histogram) StdDev() time.Duration {
+	if h.totalCount == 0 {
+		return 0
+	}
+
+	var mean = h.sum / float64(h.totalCount)
+	var variance = h.sumSquares/float64(h.totalCount) - mean*mean
+	if variance < 0 {
+		variance = 0
+	}
+
+	return time.Duration(math.Sqrt(variance))
+}
+
+// ValueAtPercentile returns a value below which a given percentage (0-100) of recorded values fall.
+// The result is the highest value equivalent to the found bucket and never exceeds the recorded maximum
+func (h *Histogram) ValueAtPercentile(percentile float64) time.Duration {
+	if h.totalCount == 0 {
+		return 0
+	}
+
+	if percentile > 100 {
+		percentile = 100
+	}
+
+	var targetCount = int64(math.Ceil(percentile / 100 * float64(h.totalCount)))
+	if targetCount < 1 {
+		targetCount = 1
+	}
+
+	var cumulativeCount int64
+	for i, count := range h.counts {
+		cumulativeCount += count
+		if cumulativeCount >= targetCount {
+			var value = time.Duration(highestEquivalentValue(i)) * time.Microsecond
+			if value > h.max {
+				return h.max
+			}
+			if value < h.min {
+				return h.min
+			}
+			return value
+		}
+	}
+
+	return h.max
+}
+
+// Distribution splits a range between recorded minimum and maximum into a given number of equal bins
+// and returns a number of values which fall into every bin
+func (h *Histogram) Distribution(binsCount int) []DistributionBin {
+	if h.totalCount == 0 || binsCount < 1 {
+		return nil
+	}
+
+	var binWidth = (h.max - h.min) / time.Duration(binsCount)
+	if binWidth <= 0 {
+		return []DistributionBin{{From: h.min, To: h.max, Count: h.totalCount}}
+	}
+
+	var bins = make([]DistributionBin, binsCount)
+	for i := range bins {
+		bins[i].From = h.min + time.Duration(i)*binWidth
+		bins[i].To = bins[i].From + binWidth
+	}
+	bins[binsCount-1].To = h.max
+
+	for i, count := range h.counts {
+		if count == 0 {
+			continue
+		}
+
+		var value = time.Duration(highestEquivalentValue(i)) * time.Microsecond
+		var binIndex = int((value - h.min) / binWidth)
+		if binIndex < 0 {
+			binIndex = 0
+		}
+		if binIndex >= binsCount {
+			binIndex = binsCount - 1
+		}
+		bins[binIndex].Count += count
+	}
+
+	return bins
+}
+
+// DistributionBin holds a number of values which fall into a range [From, To)
+type DistributionBin struct {
+	From  time.Duration
+	To    time.Duration
+	Count int64
+}
+
+func (h *Histogram) ensureCountsLength(length int) {
+	if length > len(h.counts) {
+		h.counts = append(h.counts, make([]int64, length-len(h.counts))...)
+	}
+}
+
+// countsIndex maps a value to its position in the counts slice. Values lower than subBucketCount
+// are stored linearly, every following bucket doubles the covered range keeping subBucketHalfCount slots
+func countsIndex(value int64) int {
+	if value < subBucketCount {
+		return int(value)
+	}
+
+	var shift = bits.Len64(uint64(value)) - subBucketBits
+	var subBucketIndex = int(value >> uint(shift))
+	return shift*subBucketHalfCount + subBucketIndex
+}
+
+// highestEquivalentValue returns the highest value which is stored at a given counts index
+func highestEquivalentValue(index int) int64 {
+	if index < subBucketCount {
+		return int64(index)
+	}
+
+	var shift = index/subBucketHalfCount - 1
+	var subBucketIndex = int64(index - shift*subBucketHalfCount)
+	return ((subBucketIndex + 1) << uint(shift)) - 1
+}
@@ -0,0 +1,153 @@
+package stats
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestNewHistogramIsEmpty(t *testing.T) {
+	var histogram = NewHistogram()
+
+	if histogram.Count() != 0 || histogram.Min() != 0 || histogram.Max() != 0 || histogram.Mean() != 0 ||
+		histogram.StdDev() != 0 || histogram.ValueAtPercentile(99) != 0 {
+		t.Errorf("Empty histogram should report zero values: %v", histogram)
+	}
+}
+
+func TestHistogram_RecordExactValues(t *testing.T) {
+	var histogram = NewHistogram()
+	var givenValues = []time.Duration{2 * time.Millisecond, 4 * time.Millisecond, 6 * time.Millisecond}
+
+	for _, value := range givenValues {
+		histogram.Record(value)
+	}
+
+	if histogram.Count() != 3 {
+		t.Errorf("Unexpected count, actual: %d, expected: %d", histogram.Count(), 3)
+	}
+
+	if histogram.Min() != 2*time.Millisecond || histogram.Max() != 6*time.Millisecond || histogram.Mean() != 4*time.Millisecond {
+		t.Errorf("Unexpected min/max/mean: %s/%s/%s", histogram.Min(), histogram.Max(), histogram.Mean())
+	}
+
+	if histogram.Sum() != 12*time.Millisecond {
+		t.Errorf("Unexpected sum: %s", histogram.Sum())
+	}
+
+	var expectedStdDev = time.Duration(math.Sqrt(8.0/3.0) * float64(time.Millisecond))
+	if math.Abs(float64(histogram.StdDev()-expectedStdDev)) > float64(time.Microsecond) {
+		t.Errorf("Unexpected standard deviation, actual: %s, expected: %s", histogram.StdDev(), expectedStdDev)
+	}
+}
+
+func TestHistogram_RecordNegativeValueAsZero(t *testing.T) {
+	var histogram = NewHistogram()
+	histogram.Record(-time.Second)
+
+	if histogram.Min() != 0 || histogram.Max() != 0 || histogram.Count() != 1 {
+		t.Errorf("Negative value should be recorded as zero: %v", histogram)
+	}
+}
+
+func TestHistogram_ValueAtPercentile(t *testing.T) {
+	var histogram = NewHistogram()
+	for i := 1; i <= 10000; i++ {
+		histogram.Record(time.Duration(i) * time.Millisecond)
+	}
+
+	var expectations = map[float64]time.Duration{
+		50:   5000 * time.Millisecond,
+		90:   9000 * time.Millisecond,
+		99:   9900 * time.Millisecond,
+		99.9: 9990 * time.Millisecond,
+		100:  10000 * time.Millisecond,
+	}
+
+	for percentile, expected := range expectations {
+		var actual = histogram.ValueAtPercentile(percentile)
+		var relativeError = math.Abs(float64(actual-expected)) / float64(expected)
+
+		if relativeError > 0.01 {
+			t.Errorf("Unexpected p%v, actual: %s, expected: %s", percentile, actual, expected)
+		}
+	}
+}
+
+func TestHistogram_ValueAtPercentileDoesNotExceedMax(t *testing.T) {
+	var histogram = NewHistogram()
+	var givenValue = 123456789 * time.Nanosecond
+	histogram.Record(givenValue)
+
+	if histogram.ValueAtPercentile(100) != givenValue || histogram.ValueAtPercentile(0) != givenValue {
+		t.Errorf("Percentile of a single value histogram should be equal to that value, actual: %s", histogram.ValueAtPercentile(100))
+	}
+}
+
+func TestHistogram_Merge(t *testing.T) {
+	var first = NewHistogram()
+	var second = NewHistogram()
+	first.Record(time.Millisecond)
+	second.Record(3 * time.Millisecond)
+
+	first.Merge(second)
+	first.Merge(nil)
+
+	if first.Count() != 2 || first.Min() != time.Millisecond || first.Max() != 3*time.Millisecond || first.Mean() != 2*time.Millisecond {
+		t.Errorf("Unexpected merge result: %v", first)
+	}
+}
+
+func TestCountsIndexIsMonotonic(t *testing.T) {
+	var previousIndex = -1
+	for value := int64(0); value < 1<<20; value += 7 {
+		var index = countsIndex(value)
+		if index < previousIndex {
+			t.Fatalf("Index for value %d is lower than previous one: %d < %d", value, index, previousIndex)
+		}
+
+		if highestEquivalentValue(index) < value {
+			t.Fatalf("Highest equivalent value %d of index %d is lower than value %d", highestEquivalentValue(index), index, value)
+		}
+		previousIndex = index
+	}
+
+	// every bucket following the first one adds subBucketHalfCount slots for one more bit of a value
+	if countsIndex(math.MaxInt64) >= (64-subBucketBits+2)*subBucketHalfCount {
+		t.Error("Maximum value should fit into histogram counts")
+	}
+}
+
+func TestHistogram_Distribution(t *testing.T) {
+	var histogram = NewHistogram()
+	for i := 0; i < 100; i++ {
+		histogram.Record(time.Duration(i) * time.Millisecond)
+	}
+
+	var bins = histogram.Distribution(10)
+
+	if len(bins) != 10 || bins[0].From != 0 || bins[9].To != 99*time.Millisecond {
+		t.Fatalf("Unexpected distribution bins: %v", bins)
+	}
+
+	var total int64
+	for _, bin := range bins {
+		total += bin.Count
+	}
+
+	if total != 100 {
+		t.Errorf("Distribution should cover every recorded value, actual: %d", total)
+	}
+}
+
+func TestHistogram_DistributionForSingleValue(t *testing.T) {
+	var histogram = NewHistogram()
+	histogram.Record(time.Second)
+	histogram.Record(time.Second)
+
+	var bins = histogram.Distribution(10)
+
+	if len(bins) != 1 || bins[0].Count != 2 || NewHistogram().Distribution(10) != nil {
+		t.Errorf("Unexpected distribution bins: %v", bins)
+	}
+}
@@ -0,0 +1,278 @@
+package stats
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ClassTransportErrors is a name of a class which aggregates requests that did not receive any HTTP response
+const ClassTransportErrors = "errors"
+
+// Result holds the information about a single performed request
+type Result struct {
+	ThreadID   int
+	StartTime  time.Time
+	URL        string
+	Latency    time.Duration
+	StatusCode int
+	Bytes      int64
+	Err        error
+	ErrorClass ErrorClass
+
+	// Stage is a number of a stage of a load profile the request belongs to starting from 1 or 0 when there are no stages
+	Stage int
+
+	// Connection tells whether the request was sent over a new or a reused connection, it's empty when the request
+	// didn't obtain any connection, e.g. when it failed to connect
+	Connection Connection
+}
+
+// Connection describes how a request obtained a connection
+type Connection string
+
+const (
+	ConnectionNew    Connection = "new"
+	ConnectionReused Connection = "reused"
+)
+
+// ResultWriter receives every result recorded by a Recorder, e.g. in order to persist raw results
+type ResultWriter interface {
+	WriteResult(result Result) error
+}
+
+// Recorder collects results of performed requests from concurrently running threads
+// and aggregates them into a Summary once execution is over
+type Recorder struct {
+	mutex        sync.Mutex
+	latency      *Histogram
+	classLatency map[string]*Histogram
+	statusCodes  map[int]int64
+	errorClasses map[ErrorClass]int64
+	stages       map[int]*stageRecord
+	thinkTime    *Histogram
+	connections  map[Connection]int64
+	startTime    time.Time
+	endTime      time.Time
+
+	writers  []ResultWriter
+	writeErr error
+}
+
+// NewRecorder creates an empty Recorder
+func NewRecorder() *Recorder {
+	return &Recorder{
+		latency:      NewHistogram(),
+		classLatency: make(map[string]*Histogram),
+		statusCodes:  make(map[int]int64),
+		errorClasses: make(map[ErrorClass]int64),
+		stages:       make(map[int]*stageRecord),
+		thinkTime:    NewHistogram(),
+		connections:  make(map[Connection]int64),
+	}
+}
+
+// stageRecord aggregates results of a single stage of a load profile
+type stageRecord struct {
+	latency   *Histogram
+	failures  int64
+	startTime time.Time
+	endTime   time.Time
+}
+
+// Start marks the beginning of an execution. The wall-clock time of a run is measured from this moment
+func (r *Recorder) Start() {
+	r.StartAt(time.Now())
+}
+
+// StartAt marks the beginning of an execution at a given time, e.g. when results are restored from a file
+func (r *Recorder) StartAt(startTime time.Time) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.startTime = startTime
+}
+
+// Stop marks the end of an execution
+func (r *Recorder) Stop() {
+	r.StopAt(time.Now())
+}
+
+// StopAt marks the end of an execution at a given time
+func (r *Recorder) StopAt(endTime time.Time) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.endTime = endTime
+}
+
+// AddWriter registers a ResultWriter which will receive every following recorded result
+func (r *Recorder) AddWriter(writer ResultWriter) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.writers = append(r.writers, writer)
+}
+
+// WriteErr returns the first error returned by any of registered writers
+func (r *Recorder) WriteErr() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.writeErr
+}
+
+// Record adds a result of a single request and passes it to registered writers.
+// When result.Err is set and result.ErrorClass is empty the error class is determined with ClassifyError.
+// It's safe to call Record from multiple goroutines
+func (r *Recorder) Record(result Result) {
+	if result.Err != nil && result.ErrorClass == "" {
+		result.ErrorClass = ClassifyError(result.Err)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.latency.Record(result.Latency)
+
+	var class string
+	if result.ErrorClass != "" {
+		class = ClassTransportErrors
+		r.errorClasses[result.ErrorClass]++
+	} else {
+		class = StatusClass(result.StatusCode)
+		r.statusCodes[result.StatusCode]++
+	}
+
+	var classHistogram, exists = r.classLatency[class]
+	if !exists {
+		classHistogram = NewHistogram()
+		r.classLatency[class] = classHistogram
+	}
+	classHistogram.Record(result.Latency)
+
+	if result.Stage > 0 {
+		r.recordStage(result)
+	}
+
+	if result.Connection != "" {
+		r.connections[result.Connection]++
+	}
+
+	for _, writer := range r.writers {
+		if errWrite := writer.WriteResult(result); errWrite != nil && r.writeErr == nil {
+			r.writeErr = errWrite
+		}
+	}
+}
+
+// RecordThinkTime adds a pause of a thread between requests. Think times are aggregated separately
+// and never affect latency. It's safe to call RecordThinkTime from multiple goroutines
+func (r *Recorder) RecordThinkTime(thinkTime time.Duration) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.thinkTime.Record(thinkTime)
+}
+
+// recordStage adds a result to statistics of its stage
+func (r *Recorder) recordStage(result Result) {
+	var stage, exists = r.stages[result.Stage]
+	if !exists {
+		stage = &stageRecord{latency: NewHistogram(), startTime: result.StartTime}
+		r.stages[result.Stage] = stage
+	}
+
+	stage.latency.Record(result.Latency)
+	if result.ErrorClass != "" || result.StatusCode >= 400 {
+		stage.failures++
+	}
+
+	if result.StartTime.Before(stage.startTime) {
+		stage.startTime = result.StartTime
+	}
+	if endTime := result.StartTime.Add(result.Latency); endTime.After(stage.endTime) {
+		stage.endTime = endTime
+	}
+}
+
+// Summary aggregates all recorded results
+func (r *Recorder) Summary() *Summary {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var endTime = r.endTime
+	if endTime.IsZero() {
+		endTime = time.Now()
+	}
+
+	var summary = &Summary{
+		StartTime: r.startTime,
+		EndTime:   endTime,
+		Duration:  endTime.Sub(r.startTime),
+		Requests:  r.latency.Count(),
+		Latency:   NewLatencySummary(r.latency),
+	}
+
+	if summary.Duration > 0 {
+		summary.Throughput = float64(summary.Requests) / summary.Duration.Seconds()
+	}
+
+	if r.thinkTime.Count() > 0 {
+		summary.ThinkTime = ThinkTimeSummary{
+			Pauses:       r.thinkTime.Count(),
+			Total:        r.thinkTime.Sum(),
+			Distribution: NewLatencySummary(r.thinkTime),
+		}
+	}
+
+	summary.Connections = ConnectionSummary{New: r.connections[ConnectionNew], Reused: r.connections[ConnectionReused]}
+
+	for statusCode, count := range r.statusCodes {
+		summary.StatusCodes = append(summary.StatusCodes, StatusCodeCount{StatusCode: statusCode, Count: count})
+	}
+	sort.Slice(summary.StatusCodes, func(i, j int) bool {
+		return summary.StatusCodes[i].StatusCode < summary.StatusCodes[j].StatusCode
+	})
+
+	for class, histogram := range r.classLatency {
+		summary.Classes = append(summary.Classes, ClassSummary{Class: class, Count: histogram.Count(), Latency: NewLatencySummary(histogram)})
+	}
+	sort.Slice(summary.Classes, func(i, j int) bool {
+		return summary.Classes[i].Class < summary.Classes[j].Class
+	})
+
+	for errorClass, count := range r.errorClasses {
+		summary.Errors = append(summary.Errors, ErrorCount{Class: errorClass, Count: count})
+	}
+	sort.Slice(summary.Errors, func(i, j int) bool {
+		return summary.Errors[i].Count > summary.Errors[j].Count ||
+			summary.Errors[i].Count == summary.Errors[j].Count && summary.Errors[i].Class < summary.Errors[j].Class
+	})
+
+	for number, stage := range r.stages {
+		var stageSummary = StageSummary{
+			Stage:    number,
+			Requests: stage.latency.Count(),
+			Failures: stage.failures,
+			Duration: stage.endTime.Sub(stage.startTime),
+			Latency:  NewLatencySummary(stage.latency),
+		}
+
+		if stageSummary.Duration > 0 {
+			stageSummary.Throughput = float64(stageSummary.Requests) / stageSummary.Duration.Seconds()
+		}
+		summary.Stages = append(summary.Stages, stageSummary)
+	}
+	sort.Slice(summary.Stages, func(i, j int) bool {
+		return summary.Stages[i].Stage < summary.Stages[j].Stage
+	})
+
+	return summary
+}
+
+// StatusClass returns a class of a given HTTP status code in a form of '2xx'
+func StatusClass(statusCode int) string {
+	return fmt.Sprintf("%dxx", statusCode/100)
+}
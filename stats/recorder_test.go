@@ -0,0 +1,183 @@
+package stats
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRecorder_SummaryForEmptyRecorder(t *testing.T) {
+	var recorder = NewRecorder()
+	recorder.Start()
+	recorder.Stop()
+
+	var summary = recorder.Summary()
+
+	if summary.Requests != 0 || summary.Throughput != 0 || summary.Latency.Max != 0 {
+		t.Errorf("Unexpected summary for empty recorder: %v", summary)
+	}
+}
+
+func TestRecorder_RecordConcurrently(t *testing.T) {
+	var givenThreads = 10
+	var givenCount = 100
+	var recorder = NewRecorder()
+	var waitGroup = &sync.WaitGroup{}
+	waitGroup.Add(givenThreads)
+
+	recorder.Start()
+	for i := 0; i < givenThreads; i++ {
+		i := i
+		go func() {
+			defer waitGroup.Done()
+			for j := 1; j <= givenCount; j++ {
+				recorder.Record(Result{ThreadID: i, StartTime: time.Now(), Latency: time.Duration(j) * time.Millisecond})
+			}
+		}()
+	}
+	waitGroup.Wait()
+	recorder.Stop()
+
+	var summary = recorder.Summary()
+
+	if summary.Requests != int64(givenThreads*givenCount) {
+		t.Errorf("Unexpected amount of requests, actual: %d, expected: %d", summary.Requests, givenThreads*givenCount)
+	}
+
+	if summary.Latency.Min != time.Millisecond || summary.Latency.Max != 100*time.Millisecond {
+		t.Errorf("Unexpected latency summary: %v", summary.Latency)
+	}
+
+	if summary.Duration <= 0 || summary.Throughput <= 0 || summary.EndTime.Before(summary.StartTime) {
+		t.Errorf("Unexpected wall-clock details: %v", summary)
+	}
+}
+
+func TestRecorder_SummaryWithStatusCodesAndErrors(t *testing.T) {
+	var recorder = NewRecorder()
+	recorder.Start()
+	recorder.Record(Result{StatusCode: 200, Latency: 10 * time.Millisecond})
+	recorder.Record(Result{StatusCode: 200, Latency: 20 * time.Millisecond})
+	recorder.Record(Result{StatusCode: 503, Latency: time.Millisecond})
+	recorder.Record(Result{Err: timeoutError{}, Latency: time.Second})
+	recorder.Stop()
+
+	var summary = recorder.Summary()
+
+	var expectedStatusCodes = []StatusCodeCount{{StatusCode: 200, Count: 2}, {StatusCode: 503, Count: 1}}
+	if len(summary.StatusCodes) != len(expectedStatusCodes) || summary.StatusCodes[0] != expectedStatusCodes[0] || summary.StatusCodes[1] != expectedStatusCodes[1] {
+		t.Errorf("Unexpected status codes, actual: %v, expected: %v", summary.StatusCodes, expectedStatusCodes)
+	}
+
+	if len(summary.Classes) != 3 || summary.Classes[0].Class != "2xx" || summary.Classes[1].Class != "5xx" || summary.Classes[2].Class != ClassTransportErrors {
+		t.Fatalf("Unexpected classes: %v", summary.Classes)
+	}
+
+	if summary.Classes[0].Latency.Mean != 15*time.Millisecond || summary.Classes[2].Latency.Max != time.Second {
+		t.Errorf("Unexpected per-class latency: %v", summary.Classes)
+	}
+
+	if len(summary.Errors) != 1 || summary.Errors[0].Class != ErrorClassTimeout || summary.TransportErrors() != 1 {
+		t.Errorf("Unexpected transport errors: %v", summary.Errors)
+	}
+}
+
+func TestRecorder_SummaryOfStages(t *testing.T) {
+	var startTime = time.Now()
+	var recorder = NewRecorder()
+	recorder.Start()
+	recorder.Record(Result{Stage: 1, StatusCode: 200, StartTime: startTime, Latency: 10 * time.Millisecond})
+	recorder.Record(Result{Stage: 1, StatusCode: 500, StartTime: startTime.Add(time.Second), Latency: 990 * time.Millisecond})
+	recorder.Record(Result{Stage: 2, Err: timeoutError{}, StartTime: startTime.Add(2 * time.Second), Latency: time.Second})
+	recorder.Record(Result{StatusCode: 200, StartTime: startTime, Latency: time.Millisecond})
+	recorder.Stop()
+
+	var summary = recorder.Summary()
+
+	if len(summary.Stages) != 2 || summary.Stages[0].Stage != 1 || summary.Stages[1].Stage != 2 {
+		t.Fatalf("Unexpected stages: %v", summary.Stages)
+	}
+
+	var first = summary.Stages[0]
+	if first.Requests != 2 || first.Failures != 1 || first.Duration != 1990*time.Millisecond || first.Latency.Max != 990*time.Millisecond {
+		t.Errorf("Unexpected summary of the first stage: %v", first)
+	}
+
+	if summary.Stages[1].Requests != 1 || summary.Stages[1].Failures != 1 || summary.Stages[1].Throughput != 1 {
+		t.Errorf("Unexpected summary of the second stage: %v", summary.Stages[1])
+	}
+}
+
+func TestRecorder_SummaryOfThinkTime(t *testing.T) {
+	var recorder = NewRecorder()
+	recorder.Start()
+	recorder.Record(Result{StatusCode: 200, Latency: 10 * time.Millisecond})
+	recorder.RecordThinkTime(100 * time.Millisecond)
+	recorder.RecordThinkTime(300 * time.Millisecond)
+	recorder.Stop()
+
+	var summary = recorder.Summary()
+
+	if summary.ThinkTime.Pauses != 2 || summary.ThinkTime.Total != 400*time.Millisecond || summary.ThinkTime.Distribution.Mean != 200*time.Millisecond {
+		t.Errorf("Unexpected think time summary: %v", summary.ThinkTime)
+	}
+
+	if summary.Latency.Max != 10*time.Millisecond || summary.Requests != 1 {
+		t.Errorf("Think time should not affect latency: %v", summary.Latency)
+	}
+}
+
+func TestRecorder_SummaryOfConnections(t *testing.T) {
+	var recorder = NewRecorder()
+	recorder.Start()
+	recorder.Record(Result{StatusCode: 200, Connection: ConnectionNew})
+	recorder.Record(Result{StatusCode: 200, Connection: ConnectionReused})
+	recorder.Record(Result{StatusCode: 200, Connection: ConnectionReused})
+	recorder.Record(Result{StatusCode: 200, Connection: ConnectionReused})
+	recorder.Record(Result{Err: errors.New("connection refused")})
+	recorder.Stop()
+
+	var summary = recorder.Summary()
+
+	if summary.Connections != (ConnectionSummary{New: 1, Reused: 3}) || summary.Connections.ReuseRatio() != 0.75 {
+		t.Errorf("Unexpected connections summary: %v", summary.Connections)
+	}
+
+	if (ConnectionSummary{}).ReuseRatio() != 0 {
+		t.Errorf("Unexpected reuse ratio without connections")
+	}
+}
+
+func TestStatusClass(t *testing.T) {
+	if StatusClass(200) != "2xx" || StatusClass(404) != "4xx" || StatusClass(599) != "5xx" {
+		t.Error("Unexpected status class")
+	}
+}
+
+type givenResultWriter struct {
+	results []Result
+	err     error
+}
+
+func (w *givenResultWriter) WriteResult(result Result) error {
+	w.results = append(w.results, result)
+	return w.err
+}
+
+func TestRecorder_AddWriter(t *testing.T) {
+	var recorder = NewRecorder()
+	var writer = &givenResultWriter{err: errors.New("disk is full")}
+	recorder.AddWriter(writer)
+
+	recorder.Record(Result{StatusCode: 200})
+	recorder.Record(Result{Err: timeoutError{}})
+
+	if len(writer.results) != 2 || writer.results[1].ErrorClass != ErrorClassTimeout {
+		t.Errorf("Writer should receive every classified result: %v", writer.results)
+	}
+
+	if recorder.WriteErr() == nil || recorder.WriteErr().Error() != "disk is full" {
+		t.Errorf("Unexpected write error: %v", recorder.WriteErr())
+	}
+}
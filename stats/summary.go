@@ -0,0 +1,139 @@
+package stats
+
+import "time"
+
+// Summary holds aggregated statistics of an execution
+type Summary struct {
+	StartTime   time.Time
+	EndTime     time.Time
+	Duration    time.Duration
+	Requests    int64
+	Throughput  float64
+	Latency     LatencySummary
+	StatusCodes []StatusCodeCount
+	Classes     []ClassSummary
+	Errors      []ErrorCount
+
+	// Stages holds statistics of every stage of a load profile which has any recorded requests
+	Stages []StageSummary
+
+	// ThinkTime holds pauses of threads between requests which are excluded from latency
+	ThinkTime ThinkTimeSummary
+
+	// Connections holds numbers of requests sent over new and reused connections
+	Connections ConnectionSummary
+
+	// LateDispatches is a number of requests of a constant arrival rate which could not be sent at their scheduled time
+	LateDispatches int64
+
+	// Interrupted tells whether execution was stopped by a signal, so the summary covers completed requests only
+	Interrupted bool
+}
+
+// LatencySummary holds latency distribution details
+type LatencySummary struct {
+	Min    time.Duration
+	Mean   time.Duration
+	Max    time.Duration
+	StdDev time.Duration
+	P50    time.Duration
+	P90    time.Duration
+	P95    time.Duration
+	P99    time.Duration
+	P999   time.Duration
+}
+
+// StatusCodeCount holds a number of responses received with a status code
+type StatusCodeCount struct {
+	StatusCode int
+	Count      int64
+}
+
+// ClassSummary holds a number of requests and their latency for a class of responses such as '2xx'
+// or ClassTransportErrors for requests without a response
+type ClassSummary struct {
+	Class   string
+	Count   int64
+	Latency LatencySummary
+}
+
+// StageSummary holds statistics of requests of a single stage of a load profile. Its duration is measured
+// from the first scheduled request of the stage to the last received response
+type StageSummary struct {
+	Stage      int
+	Requests   int64
+	Failures   int64
+	Duration   time.Duration
+	Throughput float64
+	Latency    LatencySummary
+}
+
+// ThinkTimeSummary holds a number of pauses of threads between requests, their total duration and distribution
+type ThinkTimeSummary struct {
+	Pauses       int64
+	Total        time.Duration
+	Distribution LatencySummary
+}
+
+// ConnectionSummary holds numbers of requests sent over new connections and over connections reused from a pool
+type ConnectionSummary struct {
+	New    int64
+	Reused int64
+}
+
+// ReuseRatio returns a share of requests sent over reused connections out of all requests which obtained a connection
+func (s ConnectionSummary) ReuseRatio() float64 {
+	if total := s.New + s.Reused; total > 0 {
+		return float64(s.Reused) / float64(total)
+	}
+	return 0
+}
+
+// ErrorCount holds a number of transport failures of an ErrorClass
+type ErrorCount struct {
+	Class ErrorClass
+	Count int64
+}
+
+// NewLatencySummary calculates LatencySummary for values recorded by a given histogram
+func NewLatencySummary(histogram *Histogram) LatencySummary {
+	return LatencySummary{
+		Min:    histogram.Min(),
+		Mean:   histogram.Mean(),
+		Max:    histogram.Max(),
+		StdDev: histogram.StdDev(),
+		P50:    histogram.ValueAtPercentile(50),
+		P90:    histogram.ValueAtPercentile(90),
+		P95:    histogram.ValueAtPercentile(95),
+		P99:    histogram.ValueAtPercentile(99),
+		P999:   histogram.ValueAtPercentile(99.9),
+	}
+}
+
+// TransportErrors returns a total number of requests which did not receive any HTTP response
+func (s *Summary) TransportErrors() int64 {
+	var total int64
+	for _, errorCount := range s.Errors {
+		total += errorCount.Count
+	}
+	return total
+}
+
+// Failures returns a total number of failed requests: transport errors and responses with 4xx or 5xx status codes
+func (s *Summary) Failures() int64 {
+	var total = s.TransportErrors()
+	for _, statusCode := range s.StatusCodes {
+		if statusCode.StatusCode >= 400 {
+			total += statusCode.Count
+		}
+	}
+	return total
+}
+
+// FailureRate returns a fraction of failed requests, see Failures
+func (s *Summary) FailureRate() float64 {
+	if s.Requests == 0 {
+		return 0
+	}
+	return float64(s.Failures()) / float64(s.Requests)
+}
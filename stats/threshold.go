@@ -0,0 +1,139 @@
+package stats
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var thresholdRegex = regexp.MustCompile(`^\s*([a-z0-9_.]+)\s*(<=|>=|==|!=|<|>)\s*(\S+)\s*$`)
+
+// thresholdMetrics maps a metric name to a function which extracts its value from a Summary.
+// Latency metrics are expressed in milliseconds, rates as a fraction of total requests
+var thresholdMetrics = map[string]func(summary *Summary) float64{
+	"min":        func(s *Summary) float64 { return milliseconds(s.Latency.Min) },
+	"mean":       func(s *Summary) float64 { return milliseconds(s.Latency.Mean) },
+	"avg":        func(s *Summary) float64 { return milliseconds(s.Latency.Mean) },
+	"max":        func(s *Summary) float64 { return milliseconds(s.Latency.Max) },
+	"stddev":     func(s *Summary) float64 { return milliseconds(s.Latency.StdDev) },
+	"p50":        func(s *Summary) float64 { return milliseconds(s.Latency.P50) },
+	"p90":        func(s *Summary) float64 { return milliseconds(s.Latency.P90) },
+	"p95":        func(s *Summary) float64 { return milliseconds(s.Latency.P95) },
+	"p99":        func(s *Summary) float64 { return milliseconds(s.Latency.P99) },
+	"p99.9":      func(s *Summary) float64 { return milliseconds(s.Latency.P999) },
+	"rps":        func(s *Summary) float64 { return s.Throughput },
+	"requests":   func(s *Summary) float64 { return float64(s.Requests) },
+	"errors":     func(s *Summary) float64 { return float64(s.Failures()) },
+	"error_rate": func(s *Summary) float64 { return s.FailureRate() },
+}
+
+// Threshold is a pass/fail criteria such as 'p95<300ms', 'error_rate<1%' or 'rps>500' evaluated against a Summary
+type Threshold struct {
+	Expression string
+	Metric     string
+	Operator   string
+	Value      float64
+}
+
+// ThresholdResult holds an outcome of a Threshold evaluation. NoData is set when there are no completed requests
+// to evaluate the threshold against, such a threshold is failed
+type ThresholdResult struct {
+	Threshold *Threshold
+	Actual    float64
+	Passed    bool
+	NoData    bool
+}
+
+// ParseThreshold parses an expression in a form of '<metric><operator><value>'. Supported metrics are:
+// min, mean (avg), max, stddev, p50, p90, p95, p99, p99.9, rps, requests, errors and error_rate.
+// Latency values are durations such as '300ms' or '1.5s' (a plain number means milliseconds),
+// error_rate values are percentages such as '1%' or fractions such as '0.01'
+func ParseThreshold(expression string) (*Threshold, error) {
+	var match = thresholdRegex.FindStringSubmatch(strings.ToLower(expression))
+	if match == nil {
+		return nil, fmt.Errorf("threshold '%s' should be in a form of '<metric><operator><value>', e.g. 'p95<300ms'", expression)
+	}
+
+	var threshold = &Threshold{Expression: strings.TrimSpace(expression), Metric: match[1], Operator: match[2]}
+	if _, exists := thresholdMetrics[threshold.Metric]; !exists {
+		return nil, fmt.Errorf("threshold '%s' has unknown metric '%s'", expression, threshold.Metric)
+	}
+
+	var value, errValue = parseThresholdValue(threshold.Metric, match[3])
+	if errValue != nil {
+		return nil, fmt.Errorf("threshold '%s' has invalid value '%s': %s", expression, match[3], errValue.Error())
+	}
+	threshold.Value = value
+
+	return threshold, nil
+}
+
+func parseThresholdValue(metric string, value string) (float64, error) {
+	switch metric {
+	case "rps", "requests", "errors":
+		return strconv.ParseFloat(value, 64)
+
+	case "error_rate":
+		if strings.HasSuffix(value, "%") {
+			var percent, errParse = strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+			return percent / 100, errParse
+		}
+		return strconv.ParseFloat(value, 64)
+
+	default:
+		if number, errParse := strconv.ParseFloat(value, 64); errParse == nil {
+			return number, nil
+		}
+
+		var duration, errParse = time.ParseDuration(value)
+		return milliseconds(duration), errParse
+	}
+}
+
+// Evaluate checks whether a given summary satisfies the threshold. The threshold fails with no data when
+// the summary has no completed requests
+func (t *Threshold) Evaluate(summary *Summary) ThresholdResult {
+	if summary.Requests == 0 {
+		return ThresholdResult{Threshold: t, NoData: true}
+	}
+
+	var actual = thresholdMetrics[t.Metric](summary)
+	var passed bool
+
+	switch t.Operator {
+	case "<":
+		passed = actual < t.Value
+	case "<=":
+		passed = actual <= t.Value
+	case ">":
+		passed = actual > t.Value
+	case ">=":
+		passed = actual >= t.Value
+	case "==":
+		passed = actual == t.Value
+	case "!=":
+		passed = actual != t.Value
+	}
+
+	return ThresholdResult{Threshold: t, Actual: actual, Passed: passed}
+}
+
+// FormatValue formats a metric value in units used by threshold expressions of this metric
+func (t *Threshold) FormatValue(value float64) string {
+	switch t.Metric {
+	case "rps":
+		return fmt.Sprintf("%.2f", value)
+	case "requests", "errors":
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case "error_rate":
+		return fmt.Sprintf("%.2f%%", value*100)
+	default:
+		return fmt.Sprintf("%.3fms", value)
+	}
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
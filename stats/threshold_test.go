@@ -0,0 +1,83 @@
+package stats
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseThreshold(t *testing.T) {
+	var expectations = []struct {
+		expression string
+		metric     string
+		operator   string
+		value      float64
+	}{
+		{"p95<300ms", "p95", "<", 300},
+		{" p99.9 <= 1.5s ", "p99.9", "<=", 1500},
+		{"mean<250", "mean", "<", 250},
+		{"error_rate<1%", "error_rate", "<", 0.01},
+		{"error_rate<=0.05", "error_rate", "<=", 0.05},
+		{"rps>500", "rps", ">", 500},
+		{"errors==0", "errors", "==", 0},
+	}
+
+	for _, expectation := range expectations {
+		var threshold, errParse = ParseThreshold(expectation.expression)
+		if errParse != nil {
+			t.Errorf("An error is not expected for '%s': %v", expectation.expression, errParse)
+			continue
+		}
+
+		if threshold.Metric != expectation.metric || threshold.Operator != expectation.operator || threshold.Value != expectation.value {
+			t.Errorf("Unexpected threshold for '%s': %+v", expectation.expression, threshold)
+		}
+	}
+}
+
+func TestParseInvalidThreshold(t *testing.T) {
+	for _, expression := range []string{"", "p95", "p95~300ms", "latency<300ms", "p95<fast", "rps>many", "error_rate<a%"} {
+		if _, errParse := ParseThreshold(expression); errParse == nil {
+			t.Errorf("An error is expected for '%s'", expression)
+		}
+	}
+}
+
+func TestThreshold_Evaluate(t *testing.T) {
+	var summary = &Summary{
+		Requests:    200,
+		Throughput:  600,
+		Latency:     LatencySummary{P95: 250 * time.Millisecond},
+		StatusCodes: []StatusCodeCount{{StatusCode: 200, Count: 196}, {StatusCode: 503, Count: 2}},
+		Errors:      []ErrorCount{{Class: ErrorClassTimeout, Count: 2}},
+	}
+
+	var expectations = map[string]bool{
+		"p95<300ms":      true,
+		"p95<250ms":      false,
+		"p95<=250ms":     true,
+		"rps>500":        true,
+		"rps>=1000":      false,
+		"error_rate<1%":  false,
+		"error_rate<=2%": true,
+		"errors!=0":      true,
+		"requests==200":  true,
+	}
+
+	for expression, expected := range expectations {
+		var threshold, _ = ParseThreshold(expression)
+		if result := threshold.Evaluate(summary); result.Passed != expected {
+			t.Errorf("Unexpected evaluation of '%s', actual: %v (%v), expected: %v", expression, result.Passed, result.Actual, expected)
+		}
+	}
+}
+
+func TestThreshold_EvaluateEmptySummary(t *testing.T) {
+	var summary = &Summary{}
+
+	for _, expression := range []string{"p95<1s", "error_rate<1%", "errors==0", "requests>=0"} {
+		var threshold, _ = ParseThreshold(expression)
+		if result := threshold.Evaluate(summary); result.Passed || !result.NoData {
+			t.Errorf("Threshold '%s' should fail with no data for an empty summary, actual: %v", expression, result)
+		}
+	}
+}
@@ -34,35 +34,96 @@ func InitMultiProgress(threads int, count int) *ProgressWrapper {
 	}
 }
 
+// InitRateProgress creates ProgressWrapper with a single progress component of a given total number of requests
+// shared by all workers of a constant arrival rate. The only bar has ID 0
+func InitRateProgress(count int) *ProgressWrapper {
+	var progressWrapper = InitMultiProgress(1, count)
+	progressWrapper.description = "Scheduled"
+	return progressWrapper
+}
+
+// timedProgressInterval is an interval at which progress components of a fixed duration are advanced
+const timedProgressInterval = 100 * time.Millisecond
+
+// InitTimedProgress creates ProgressWrapper with progress components for a given number of threads which are advanced
+// by time elapsed out of a given duration rather than by performed requests. A given describe function is called with
+// an ID of a bar on every refresh of the bar to display a current state of execution, e.g. a thread or a current stage
+func InitTimedProgress(threads int, duration time.Duration, describe func(threadID int) string) *ProgressWrapper {
+	var progressWrapper = InitMultiProgress(threads, int(duration/time.Millisecond))
+	progressWrapper.duration = duration
+	progressWrapper.describe = describe
+	return progressWrapper
+}
+
 // AddBar creates *mpb.Bar and adds newly created progress component to progressBars slice.
 // A threadID determines an ID of a Thread and also slice's index.
 // This scenario applicable if pw.multiProgress initialized and progress mode not silent
 func (pw *ProgressWrapper) AddBar(threadID int) {
 	if pw.multiProgress != nil && !pw.silent {
 		var threadDescription = cyanColor.Sprintf("Thread #%-4d", threadID)
+		if pw.description != "" {
+			threadDescription = cyanColor.Sprintf("%-12s", pw.description)
+		}
 		var onCompleteDecorator = decor.OnComplete(
 			decor.EwmaETA(decor.ET_STYLE_GO, 10),
 			greenColor.Sprintf("DONE"),
 		)
 
+		var nameDecorator = decor.Name(threadDescription)
+		if pw.duration > 0 {
+			onCompleteDecorator = decor.OnComplete(decor.AverageETA(decor.ET_STYLE_GO), greenColor.Sprintf("DONE"))
+			nameDecorator = newDescriptionDecorator(func() string {
+				return pw.describe(threadID)
+			})
+		}
+
 		var progress = pw.multiProgress.AddBar(int64(pw.count),
 			mpb.BarStyle("╢▌▌ ╟"),
 			mpb.PrependDecorators(
-				decor.Name(threadDescription),
+				nameDecorator,
 				decor.Percentage(decor.WCSyncSpace),
 			),
 			mpb.AppendDecorators(onCompleteDecorator),
 		)
 
 		pw.progressBars[threadID] = progress
+		if pw.duration > 0 {
+			go pw.advanceByTime(progress)
+		}
+	}
+}
+
+// advanceByTime advances a given progress component by milliseconds elapsed since now until the duration
+// of the wrapper is over or the component is completed
+func (pw *ProgressWrapper) advanceByTime(progress *mpb.Bar) {
+	var startTime = time.Now()
+	var ticker = time.NewTicker(timedProgressInterval)
+	defer ticker.Stop()
+
+	var advanced int64
+	for range ticker.C {
+		if progress.Completed() {
+			return
+		}
+
+		var elapsed = int64(time.Since(startTime) / time.Millisecond)
+		if elapsed > int64(pw.count) {
+			elapsed = int64(pw.count)
+		}
+
+		progress.IncrBy(int(elapsed - advanced))
+		advanced = elapsed
+		if advanced == int64(pw.count) {
+			return
+		}
 	}
 }
 
 // Increment increments a value of progress bar with ID on 1 item.
-// This scenario applicable if pw.multiProgress initialized, pw.progressBars[threadID] is present
-// and progress mode not silent
+// This scenario applicable if pw.multiProgress initialized, pw.progressBars[threadID] is present,
+// progress mode not silent and the bar is not advanced by time
 func (pw *ProgressWrapper) Increment(threadID int, timeSince time.Duration) {
-	if pw.multiProgress != nil && !pw.silent && pw.progressBars[threadID] != nil {
+	if pw.multiProgress != nil && !pw.silent && pw.duration == 0 && pw.progressBars[threadID] != nil {
 		pw.progressBars[threadID].IncrBy(1, timeSince)
 	}
 }
@@ -103,4 +164,29 @@ type ProgressWrapper struct {
 	threads       int
 	count         int
 	silent        bool
+
+	// description replaces thread numbers of bars when it's set
+	description string
+
+	// duration is a total duration of bars which are advanced by time rather than by performed requests.
+	// Their state is described by describe function
+	duration time.Duration
+	describe func(threadID int) string
+}
+
+// descriptionDecorator is a name decorator which text is provided by a function on every refresh of a bar
+type descriptionDecorator struct {
+	decor.WC
+	describe func() string
+}
+
+func newDescriptionDecorator(describe func() string) decor.Decorator {
+	var wc = decor.WC{}
+	wc.Init()
+	return &descriptionDecorator{WC: wc, describe: describe}
+}
+
+// Decor returns the current description
+func (d *descriptionDecorator) Decor(*decor.Statistics) string {
+	return d.FormatMsg(cyanColor.Sprintf("%-12s", d.describe()))
 }
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"strings"
 	"testing"
 	"time"
 )
@@ -166,3 +167,53 @@ func TestProgressWrapper_WaitForCompletion(t *testing.T) {
 
 	pw.WaitForCompletion()
 }
+
+func TestInitRateProgress(t *testing.T) {
+	var pw = InitRateProgress(100)
+
+	if pw.silent || pw.threads != 1 || pw.count != 100 || len(pw.progressBars) != 1 || pw.description == "" {
+		t.Errorf("Unexpected rate ProgressWrapper %+v", pw)
+	}
+}
+
+func TestInitTimedProgress(t *testing.T) {
+	var pw = InitTimedProgress(3, 2*time.Second, func(threadID int) string { return "Stage 1/2" })
+
+	if pw.silent || pw.count != 2000 || pw.duration != 2*time.Second || len(pw.progressBars) != 3 {
+		t.Errorf("Unexpected timed ProgressWrapper %+v", pw)
+	}
+}
+
+func TestProgressWrapper_TimedBarAdvancesByTime(t *testing.T) {
+	var pw = InitTimedProgress(1, 300*time.Millisecond, func(threadID int) string { return "Stage 1/1" })
+	pw.AddBar(0)
+	pw.Increment(0, time.Millisecond)
+
+	if pw.progressBars[0].Current() != 0 {
+		t.Error("Timed progressBar should not be incremented by requests")
+	}
+
+	go func() {
+		defer pw.DoneExecution()
+		time.Sleep(150 * time.Millisecond)
+		if current := pw.progressBars[0].Current(); current < 50 || current > 250 {
+			t.Errorf("Unexpected progress %d of timed progressBar", current)
+		}
+	}()
+
+	pw.WaitForCompletion()
+}
+
+func TestDescriptionDecorator(t *testing.T) {
+	var description = "Stage 1/2"
+	var decorator = newDescriptionDecorator(func() string { return description })
+
+	if actual := decorator.Decor(nil); !strings.Contains(actual, "Stage 1/2") {
+		t.Errorf("Unexpected description '%s'", actual)
+	}
+
+	description = "Stage 2/2"
+	if actual := decorator.Decor(nil); !strings.Contains(actual, "Stage 2/2") {
+		t.Errorf("Unexpected description '%s'", actual)
+	}
+}
@@ -0,0 +1,206 @@
+package ui
+
+import (
+	"fmt"
+	"github.com/fatih/color"
+	"github.com/vkrava4/curlson/stats"
+	"io"
+	"time"
+)
+
+var boldColor = color.New(color.Bold)
+var yellowColor = color.New(color.FgYellow)
+var redColor = color.New(color.FgRed)
+
+// PrintSummary prints an end-of-run statistic report to the console output
+func PrintSummary(summary *stats.Summary) {
+	WriteSummary(color.Output, summary)
+}
+
+// WriteSummary writes an end-of-run statistic report for a given summary to w
+func WriteSummary(w io.Writer, summary *stats.Summary) {
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, boldColor.Sprint("Summary"))
+	_, _ = fmt.Fprintf(w, "   %-18s %d\n", "Total requests:", summary.Requests)
+	_, _ = fmt.Fprintf(w, "   %-18s %s\n", "Wall-clock time:", formatDuration(summary.Duration))
+	_, _ = fmt.Fprintf(w, "   %-18s %.2f req/s\n", "Throughput:", summary.Throughput)
+	if summary.LateDispatches > 0 {
+		_, _ = fmt.Fprintf(w, "   %-18s %s\n", "Late dispatches:", yellowColor.Sprintf("%d", summary.LateDispatches))
+	}
+
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, boldColor.Sprint("Latency"))
+	writeLatencyRow(w, []string{"Min", "Mean", "Max", "StdDev"},
+		[]time.Duration{summary.Latency.Min, summary.Latency.Mean, summary.Latency.Max, summary.Latency.StdDev})
+	writeLatencyRow(w, []string{"p50", "p90", "p95", "p99", "p99.9"},
+		[]time.Duration{summary.Latency.P50, summary.Latency.P90, summary.Latency.P95, summary.Latency.P99, summary.Latency.P999})
+
+	writeThinkTime(w, summary)
+	writeConnections(w, summary)
+	writeStages(w, summary)
+	writeClasses(w, summary)
+	writeStatusCodes(w, summary)
+	writeErrors(w, summary)
+	_, _ = fmt.Fprintln(w)
+}
+
+func writeThinkTime(w io.Writer, summary *stats.Summary) {
+	if summary.ThinkTime.Pauses == 0 {
+		return
+	}
+
+	var thinkTime = summary.ThinkTime
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, boldColor.Sprint("Think time")+" (excluded from latency)")
+	_, _ = fmt.Fprintf(w, "   %-18s %d\n", "Pauses:", thinkTime.Pauses)
+	_, _ = fmt.Fprintf(w, "   %-18s %s\n", "Total:", formatDuration(thinkTime.Total))
+	writeLatencyRow(w, []string{"Min", "Mean", "p50", "p95", "Max"},
+		[]time.Duration{thinkTime.Distribution.Min, thinkTime.Distribution.Mean, thinkTime.Distribution.P50, thinkTime.Distribution.P95, thinkTime.Distribution.Max})
+}
+
+func writeConnections(w io.Writer, summary *stats.Summary) {
+	var connections = summary.Connections
+	if connections.New+connections.Reused == 0 {
+		return
+	}
+
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, boldColor.Sprint("Connections"))
+	_, _ = fmt.Fprintf(w, "   %-18s %d\n", "New:", connections.New)
+	_, _ = fmt.Fprintf(w, "   %-18s %d\n", "Reused:", connections.Reused)
+	_, _ = fmt.Fprintf(w, "   %-18s %.2f%%\n", "Reuse ratio:", connections.ReuseRatio()*100)
+}
+
+func writeStages(w io.Writer, summary *stats.Summary) {
+	if len(summary.Stages) == 0 {
+		return
+	}
+
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, boldColor.Sprint("Stages"))
+	_, _ = fmt.Fprintf(w, "   %-6s %-10s %-9s %-12s %-12s %-12s %-12s %-12s\n", "Stage", "Requests", "Failures", "Throughput", "Mean", "p95", "p99", "Max")
+	for _, stage := range summary.Stages {
+		var failures = fmt.Sprintf("%-9d", stage.Failures)
+		if stage.Failures > 0 {
+			failures = redColor.Sprint(failures)
+		}
+
+		_, _ = fmt.Fprintf(w, "   %s %-10d %s %-12s %-12s %-12s %-12s %-12s\n",
+			cyanColor.Sprintf("%-6d", stage.Stage), stage.Requests, failures, fmt.Sprintf("%.2f/s", stage.Throughput),
+			formatDuration(stage.Latency.Mean), formatDuration(stage.Latency.P95), formatDuration(stage.Latency.P99),
+			formatDuration(stage.Latency.Max))
+	}
+}
+
+func writeClasses(w io.Writer, summary *stats.Summary) {
+	if len(summary.Classes) == 0 {
+		return
+	}
+
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, boldColor.Sprint("Responses"))
+	_, _ = fmt.Fprintf(w, "   %-8s %-10s %-9s %-12s %-12s %-12s %-12s %-12s\n", "Class", "Count", "Share", "Mean", "p50", "p95", "p99", "Max")
+	for _, class := range summary.Classes {
+		var share float64
+		if summary.Requests > 0 {
+			share = float64(class.Count) / float64(summary.Requests) * 100
+		}
+
+		_, _ = fmt.Fprintf(w, "   %s %-10d %-9s %-12s %-12s %-12s %-12s %-12s\n",
+			classColor(class.Class).Sprintf("%-8s", class.Class), class.Count, fmt.Sprintf("%.2f%%", share),
+			formatDuration(class.Latency.Mean), formatDuration(class.Latency.P50), formatDuration(class.Latency.P95),
+			formatDuration(class.Latency.P99), formatDuration(class.Latency.Max))
+	}
+}
+
+func writeStatusCodes(w io.Writer, summary *stats.Summary) {
+	if len(summary.StatusCodes) == 0 {
+		return
+	}
+
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, boldColor.Sprint("Status codes"))
+	for _, statusCode := range summary.StatusCodes {
+		_, _ = fmt.Fprintf(w, "   %s %d\n", classColor(stats.StatusClass(statusCode.StatusCode)).Sprintf("%-8d", statusCode.StatusCode), statusCode.Count)
+	}
+}
+
+func writeErrors(w io.Writer, summary *stats.Summary) {
+	if len(summary.Errors) == 0 {
+		return
+	}
+
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, boldColor.Sprint("Transport errors"))
+	for _, errorCount := range summary.Errors {
+		_, _ = fmt.Fprintf(w, "   %s %d\n", redColor.Sprintf("%-24s", errorCount.Class), errorCount.Count)
+	}
+}
+
+// classColor returns a color which corresponds to a class of responses
+func classColor(class string) *color.Color {
+	switch class {
+	case "2xx":
+		return greenColor
+	case "3xx", "1xx":
+		return cyanColor
+	case "4xx":
+		return yellowColor
+	default:
+		return redColor
+	}
+}
+
+func writeLatencyRow(w io.Writer, names []string, values []time.Duration) {
+	_, _ = fmt.Fprint(w, "  ")
+	for _, name := range names {
+		_, _ = fmt.Fprintf(w, " %-12s", name)
+	}
+	_, _ = fmt.Fprintln(w)
+
+	_, _ = fmt.Fprint(w, "  ")
+	for _, value := range values {
+		_, _ = fmt.Fprint(w, " "+cyanColor.Sprintf("%-12s", formatDuration(value)))
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
+// formatDuration rounds a duration to a human readable precision depending on its magnitude
+func formatDuration(d time.Duration) string {
+	switch {
+	case d >= time.Second:
+		return d.Round(time.Millisecond).String()
+	case d >= time.Millisecond:
+		return d.Round(10 * time.Microsecond).String()
+	default:
+		return d.Round(time.Microsecond).String()
+	}
+}
+
+// PrintThresholds prints outcomes of thresholds evaluation to the console output
+func PrintThresholds(results []stats.ThresholdResult) {
+	WriteThresholds(color.Output, results)
+}
+
+// WriteThresholds writes outcomes of thresholds evaluation to w
+func WriteThresholds(w io.Writer, results []stats.ThresholdResult) {
+	if len(results) == 0 {
+		return
+	}
+
+	_, _ = fmt.Fprintln(w, boldColor.Sprint("Thresholds"))
+	for _, result := range results {
+		var status = greenColor.Sprint("PASSED")
+		if !result.Passed {
+			status = redColor.Sprint("FAILED")
+		}
+
+		var actual = result.Threshold.FormatValue(result.Actual)
+		if result.NoData {
+			actual = "no data"
+		}
+
+		_, _ = fmt.Fprintf(w, "   %s %-24s actual: %s\n", status, result.Threshold.Expression, actual)
+	}
+	_, _ = fmt.Fprintln(w)
+}
@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"bytes"
+	"github.com/vkrava4/curlson/stats"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteSummary(t *testing.T) {
+	var buffer = &bytes.Buffer{}
+	var givenSummary = &stats.Summary{
+		Duration:   2 * time.Second,
+		Requests:   200,
+		Throughput: 100,
+		Latency: stats.LatencySummary{
+			Min:  time.Millisecond,
+			P999: 1500 * time.Millisecond,
+		},
+		StatusCodes: []stats.StatusCodeCount{{StatusCode: 200, Count: 150}, {StatusCode: 503, Count: 40}},
+		Classes: []stats.ClassSummary{
+			{Class: "2xx", Count: 150},
+			{Class: "5xx", Count: 40},
+			{Class: stats.ClassTransportErrors, Count: 10},
+		},
+		Errors: []stats.ErrorCount{{Class: stats.ErrorClassTimeout, Count: 10}},
+
+		LateDispatches: 7,
+		ThinkTime:      stats.ThinkTimeSummary{Pauses: 3, Total: 900 * time.Millisecond, Distribution: stats.LatencySummary{Mean: 300 * time.Millisecond}},
+		Stages:         []stats.StageSummary{{Stage: 2, Requests: 120, Failures: 3, Throughput: 60.5, Latency: stats.LatencySummary{Max: 250 * time.Millisecond}}},
+		Connections:    stats.ConnectionSummary{New: 10, Reused: 190},
+	}
+
+	WriteSummary(buffer, givenSummary)
+
+	var output = buffer.String()
+	for _, expected := range []string{"Total requests:", "200", "100.00 req/s", "p99.9", "1ms", "1.5s", "503", "75.00%", "timeout", "Late dispatches:",
+		"Stages", "120", "60.50/s", "250ms", "Think time", "excluded from latency", "900ms", "300ms",
+		"Connections", "Reuse ratio:", "95.00%"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Summary output should contain '%s', actual output: %s", expected, output)
+		}
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	var expectations = map[time.Duration]string{
+		1234567 * time.Microsecond: "1.235s",
+		1234567 * time.Nanosecond:  "1.23ms",
+		1234 * time.Nanosecond:     "1µs",
+	}
+
+	for given, expected := range expectations {
+		if actual := formatDuration(given); actual != expected {
+			t.Errorf("Unexpected formatted duration, actual: '%s', expected: '%s'", actual, expected)
+		}
+	}
+}
+
+func TestWriteThresholds(t *testing.T) {
+	var buffer = &bytes.Buffer{}
+	var givenPassed, _ = stats.ParseThreshold("p95<300ms")
+	var givenFailed, _ = stats.ParseThreshold("error_rate<1%")
+	var givenNoData, _ = stats.ParseThreshold("p99<1s")
+
+	WriteThresholds(buffer, []stats.ThresholdResult{
+		{Threshold: givenPassed, Actual: 120.5, Passed: true},
+		{Threshold: givenFailed, Actual: 0.025, Passed: false},
+		{Threshold: givenNoData, NoData: true},
+	})
+
+	var output = buffer.String()
+	for _, expected := range []string{"PASSED", "p95<300ms", "120.500ms", "FAILED", "error_rate<1%", "2.50%", "p99<1s", "no data"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Thresholds output should contain '%s', actual output: %s", expected, output)
+		}
+	}
+}
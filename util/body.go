@@ -0,0 +1,63 @@
+package util
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+// DefaultBodyContentType is a content type of a request body when it's not specified explicitly, the same as in curl
+const DefaultBodyContentType = "application/x-www-form-urlencoded"
+
+// bodyStdin is a source of a request body given as '@-'
+var bodyStdin io.Reader = os.Stdin
+
+// ReadBody resolves a request body given in a curl-like form: '@-' reads the body from the standard input,
+// '@path' reads it from a file with a given path and any other value is used as the body itself
+func ReadBody(body string) ([]byte, error) {
+	switch {
+	case body == "@-":
+		return ioutil.ReadAll(bodyStdin)
+
+	case strings.HasPrefix(body, "@"):
+		return ioutil.ReadFile(strings.TrimPrefix(body, "@"))
+
+	default:
+		return []byte(body), nil
+	}
+}
+
+// RedactBody replaces a request body given in a curl-like form with RedactedValue unless it refers to a file
+// or the standard input
+func RedactBody(body string) string {
+	if body == "" || strings.HasPrefix(body, "@") {
+		return body
+	}
+
+	return RedactedValue
+}
+
+// PrepareBody resolves `#T{key}`, `#TE{key}` and `#TJ{key}` placeholders of a request body template with values of a given record.
+// The body can be of any format: use `#TJ{key}` inside JSON string literals and `#TE{key}` inside form-encoded bodies
+func PrepareBody(bodyTemplate []byte, record TemplateRecord) ([]byte, error) {
+	if len(bodyTemplate) == 0 {
+		return bodyTemplate, nil
+	}
+
+	var body = ReplacePlaceholders(string(bodyTemplate), record)
+	if ContainsTemplatePlaceholders(body) {
+		return nil, fmt.Errorf("Given request body has unresolved placeholders: '%s'", abbreviate(body, 120))
+	}
+
+	return []byte(body), nil
+}
+
+// abbreviate shortens a given text to a maximum length for log and validation messages
+func abbreviate(text string, maxLength int) string {
+	if len(text) <= maxLength {
+		return text
+	}
+	return text[:maxLength] + "..."
+}
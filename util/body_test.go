@@ -0,0 +1,106 @@
+package util
+
+import (
+	"github.com/vkrava4/curlson/app"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPrepareBody(t *testing.T) {
+	var givenBodyTemplate = []byte(`{"id":#T{0},"name":"#TJ{1}"}`)
+
+	var actual, errPrepare = PrepareBody(givenBodyTemplate, givenTemplateRecord(`7,"Jane ""J"" Doe"`))
+	if errPrepare != nil || string(actual) != `{"id":7,"name":"Jane \"J\" Doe"}` {
+		t.Errorf("Unexpected body: '%s', %v", actual, errPrepare)
+	}
+
+	if _, errPrepare = PrepareBody(givenBodyTemplate, givenTemplateRecord("7")); errPrepare == nil {
+		t.Error("An error is expected for unresolved placeholders")
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	var expectations = map[string]string{
+		"":                         "",
+		"@body.json":               "@body.json",
+		"@-":                       "@-",
+		"user=admin&password=1234": "REDACTED",
+	}
+
+	for given, expected := range expectations {
+		if actual := RedactBody(given); actual != expected {
+			t.Errorf("Unexpected redacted body of '%s', actual: '%s', expected: '%s'", given, actual, expected)
+		}
+	}
+}
+
+func TestValidateBodyWithPlaceholdersForExistingTemplate(t *testing.T) {
+	var givenFoundTemplate = "test.file"
+	var testFileAbsPath, _ = filepath.Abs(givenFoundTemplate)
+	_ = ioutil.WriteFile(testFileAbsPath, []byte("1,alice\n2,bob\n"), filesMode)
+	defer os.Remove(testFileAbsPath)
+
+	var appConf = &app.Configuration{}
+	var putValidator = &PutValidator{}
+	var validatorEntity = putValidator.AddBody(`{"id":#T{0},"name":"#TJ{1}"}`).
+		AddContentType("application/json").
+		AddRequestCount(1).
+		AddThreads(1).
+		AddUrl("http://localhost:8080/users").
+		AddTemplate(givenFoundTemplate).
+		WithAppConfiguration(appConf).
+		Entity()
+
+	var actualValidationResult = validatorEntity.Validate()
+
+	if !actualValidationResult.valid || len(actualValidationResult.errMessages) > 0 || len(actualValidationResult.warnMessages) > 0 {
+		t.Errorf("Unexpected validation result %v", actualValidationResult)
+	}
+
+	if !appConf.Request.BodyTemplated || !appConf.Template.Enabled || templateSource(appConf.Template, "").Size != 2 {
+		t.Errorf("Unexpected app configuration result %v, %v", appConf.Request, appConf.Template)
+	}
+}
+
+func TestValidateBodyWithUnresolvedPlaceholdersForExistingTemplate(t *testing.T) {
+	var givenFoundTemplate = "test.file"
+	var testFileAbsPath, _ = filepath.Abs(givenFoundTemplate)
+	_ = ioutil.WriteFile(testFileAbsPath, []byte("1,alice\n2\n"), filesMode)
+	defer os.Remove(testFileAbsPath)
+
+	var putValidator = &PutValidator{}
+	var validatorEntity = putValidator.AddBody("id=#T{0}&name=#TE{1}").
+		AddRequestCount(1).
+		AddThreads(1).
+		AddUrl("http://localhost:8080/users").
+		AddTemplate(givenFoundTemplate).
+		WithAppConfiguration(&app.Configuration{}).
+		Entity()
+
+	var actualValidationResult = validatorEntity.Validate()
+
+	if actualValidationResult.valid || len(actualValidationResult.errMessages) != 1 ||
+		actualValidationResult.errMessages[0] != "Template line 2 is invalid. Reason: there is no value for placeholder key '1'" {
+		t.Errorf("Unexpected validation result %v", actualValidationResult)
+	}
+}
+
+func TestValidateBodyWithPlaceholdersWithoutTemplate(t *testing.T) {
+	var putValidator = &PutValidator{}
+	var validatorEntity = putValidator.AddBody("id=#T{0}").
+		AddRequestCount(1).
+		AddThreads(1).
+		AddUrl("http://localhost:8080/users").
+		WithAppConfiguration(&app.Configuration{}).
+		Entity()
+
+	var actualValidationResult = validatorEntity.Validate()
+
+	if actualValidationResult.valid || len(actualValidationResult.errMessages) != 1 ||
+		!strings.HasPrefix(actualValidationResult.errMessages[0], "Provided request body is invalid") {
+		t.Errorf("Unexpected validation result %v", actualValidationResult)
+	}
+}
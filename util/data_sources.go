@@ -0,0 +1,214 @@
+package util
+
+import (
+	"fmt"
+	"github.com/vkrava4/curlson/app"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// dataSourceNameRegex matches names of data sources which are used as the first segment of placeholder keys
+var dataSourceNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_\-]*$`)
+
+// ParseDataSource parses a data source definition in a form of 'name=path'
+func ParseDataSource(definition string) (string, string, error) {
+	var separator = strings.Index(definition, "=")
+	if separator < 0 {
+		return "", "", fmt.Errorf("expected format is 'name=path'")
+	}
+
+	var name, path = strings.TrimSpace(definition[:separator]), strings.TrimSpace(definition[separator+1:])
+	if !dataSourceNameRegex.MatchString(name) {
+		return "", "", fmt.Errorf("name '%s' should start with a letter or '_' and contain only letters, digits, '_' and '-'", name)
+	}
+
+	if path == "" {
+		return "", "", fmt.Errorf("path of data source '%s' is empty", name)
+	}
+	return name, path, nil
+}
+
+// splitSourceKey splits a placeholder key into a name of a data source and a key within the source.
+// Keys which don't start with a name of a given data source belong to the unnamed template file
+func splitSourceKey(key string, sourceNames map[string]bool) (string, string) {
+	var separator = strings.Index(key, ".")
+	if separator > 0 && sourceNames[key[:separator]] {
+		return key[:separator], key[separator+1:]
+	}
+	return "", key
+}
+
+// sourceKeys returns keys within a data source with a given name out of given placeholder keys
+func sourceKeys(keys []string, name string, sourceNames map[string]bool) []string {
+	var result []string
+	for _, key := range keys {
+		if keyName, sourceKey := splitSourceKey(key, sourceNames); keyName == name {
+			result = append(result, sourceKey)
+		}
+	}
+	return result
+}
+
+// TemplateRecords joins records of several template sources into a single template record.
+// A value of key 'name.key' is taken from a record of data source 'name', other keys are taken
+// from a record of the unnamed template file
+type TemplateRecords struct {
+	records     map[string]TemplateRecord
+	sourceNames map[string]bool
+}
+
+// NewTemplateRecords creates an empty joined record of given data source names
+func NewTemplateRecords(sourceNames map[string]bool) *TemplateRecords {
+	return &TemplateRecords{records: make(map[string]TemplateRecord), sourceNames: sourceNames}
+}
+
+// Set puts a record of a data source with a given name, an empty name stands for the unnamed template file
+func (r *TemplateRecords) Set(name string, record TemplateRecord) {
+	r.records[name] = record
+}
+
+// with returns a copy of joined records with a record of a data source with a given name replaced
+func (r *TemplateRecords) with(name string, record TemplateRecord) *TemplateRecords {
+	var records = NewTemplateRecords(r.sourceNames)
+	for sourceName, sourceRecord := range r.records {
+		records.records[sourceName] = sourceRecord
+	}
+	records.records[name] = record
+	return records
+}
+
+// Value returns a value of a given placeholder key from a record of the data source the key refers to
+func (r *TemplateRecords) Value(key string) (string, bool) {
+	var name, sourceKey = splitSourceKey(key, r.sourceNames)
+	var record, exists = r.records[name]
+	if !exists || record == nil {
+		return "", false
+	}
+	return record.Value(sourceKey)
+}
+
+// Data returns data of the unnamed template file record when there are no data sources. Otherwise it returns a map
+// with data of every data source by its name, merged with fields of the unnamed template file record if it's a map
+func (r *TemplateRecords) Data() interface{} {
+	if len(r.sourceNames) == 0 {
+		if record := r.records[""]; record != nil {
+			return record.Data()
+		}
+		return nil
+	}
+
+	var data = make(map[string]interface{})
+	if record := r.records[""]; record != nil {
+		switch fields := record.Data().(type) {
+		case map[string]string:
+			for key, value := range fields {
+				data[key] = value
+			}
+
+		case map[string]interface{}:
+			for key, value := range fields {
+				data[key] = value
+			}
+		}
+	}
+
+	for name, record := range r.records {
+		if name != "" && record != nil {
+			data[name] = record.Data()
+		}
+	}
+	return data
+}
+
+// String returns lines of joined records for logging purposes
+func (r *TemplateRecords) String() string {
+	var names = make([]string, 0, len(r.records))
+	for name := range r.records {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var parts = make([]string, 0, len(names))
+	for _, name := range names {
+		if name == "" {
+			parts = append(parts, fmt.Sprint(r.records[name]))
+		} else {
+			parts = append(parts, fmt.Sprintf("%s: %s", name, r.records[name]))
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+// TemplateSources holds opened template sources of app configuration with their line selectors
+type TemplateSources struct {
+	sources     []*selectedSource
+	sourceNames map[string]bool
+}
+
+type selectedSource struct {
+	name     string
+	source   *TemplateSource
+	selector *LineSelector
+}
+
+// OpenTemplateSources opens every template source of a given configuration. Lines of every source are selected
+// independently according to its mode, random selection of each source is seeded with a distinct seed
+func OpenTemplateSources(conf *app.TemplateConfiguration, threads int) (*TemplateSources, error) {
+	var sources = &TemplateSources{sourceNames: make(map[string]bool)}
+	for i, sourceConf := range conf.Sources {
+		var source, errOpen = OpenTemplateSource(sourceConf.Path, TemplateInMemoryLimit, TemplateFormat{
+			Type:      sourceConf.Type,
+			Delimiter: sourceConf.Delimiter,
+			Header:    sourceConf.Header,
+		})
+		if errOpen != nil {
+			sources.Close()
+			return nil, fmt.Errorf("Unable to read template file '%s'. Reason: %s", sourceConf.Path, errOpen.Error())
+		}
+
+		if sourceConf.Name != "" {
+			sources.sourceNames[sourceConf.Name] = true
+		}
+
+		sources.sources = append(sources.sources, &selectedSource{
+			name:     sourceConf.Name,
+			source:   source,
+			selector: NewLineSelector(sourceConf.Mode, source.Size(), threads, conf.Seed+int64(i)),
+		})
+	}
+	return sources, nil
+}
+
+// Each calls a given function for every template source with its name
+func (s *TemplateSources) Each(apply func(name string, source *TemplateSource)) {
+	for _, source := range s.sources {
+		apply(source.name, source.source)
+	}
+}
+
+// Next selects a line of every template source for a given thread and joins their records. The returned boolean
+// is false when lines of any source are exhausted
+func (s *TemplateSources) Next(threadID int) (TemplateRecord, bool, error) {
+	var records = NewTemplateRecords(s.sourceNames)
+	for _, source := range s.sources {
+		var lineNum, hasLine = source.selector.Next(threadID)
+		if !hasLine {
+			return nil, false, nil
+		}
+
+		var record, errRecord = source.source.Record(lineNum)
+		if errRecord != nil {
+			return nil, true, fmt.Errorf("can not read line %d of template file '%s': %s", lineNum+1, source.source.path, errRecord.Error())
+		}
+		records.Set(source.name, record)
+	}
+	return records, true, nil
+}
+
+// Close closes every template source
+func (s *TemplateSources) Close() {
+	for _, source := range s.sources {
+		_ = source.source.Close()
+	}
+}
@@ -0,0 +1,205 @@
+package util
+
+import (
+	"github.com/vkrava4/curlson/app"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseDataSource(t *testing.T) {
+	var name, path, errParse = ParseDataSource("users=data/users=1.csv")
+	if errParse != nil || name != "users" || path != "data/users=1.csv" {
+		t.Errorf("Unexpected data source: '%s', '%s', %v", name, path, errParse)
+	}
+
+	for _, givenDefinition := range []string{"users.csv", "=users.csv", "us.ers=users.csv", "1users=users.csv", "users="} {
+		if _, _, errParse := ParseDataSource(givenDefinition); errParse == nil {
+			t.Errorf("An error is expected for data source '%s'", givenDefinition)
+		}
+	}
+}
+
+func TestTemplateRecordsValue(t *testing.T) {
+	var records = NewTemplateRecords(map[string]bool{"users": true, "products": true})
+	var user, _ = ParseCSVRecord("7,alice", DefaultTemplateDelimiter, map[string]int{"id": 0, "name": 1})
+	var product, _ = ParseJSONRecord(`{"sku":"A-1","price":{"amount":10}}`)
+	records.Set("", givenTemplateRecord("x,y"))
+	records.Set("users", user)
+	records.Set("products", product)
+
+	var expected = map[string]string{"users.id": "7", "users.name": "alice", "products.sku": "A-1", "products.price.amount": "10", "1": "y"}
+	for key, expectedValue := range expected {
+		if value, exists := records.Value(key); !exists || value != expectedValue {
+			t.Errorf("Unexpected value '%s' of key '%s'", value, key)
+		}
+	}
+
+	for _, key := range []string{"users.email", "orders.id", "2"} {
+		if _, exists := records.Value(key); exists {
+			t.Errorf("Key '%s' should not exist", key)
+		}
+	}
+
+	var data = records.Data().(map[string]interface{})
+	if data["1"] != "y" || !reflect.DeepEqual(data["users"], user.Data()) || !reflect.DeepEqual(data["products"], product.Data()) {
+		t.Errorf("Unexpected data %v", data)
+	}
+}
+
+func TestOpenTemplateSourcesWithModes(t *testing.T) {
+	var usersPath, _ = filepath.Abs("users.csv")
+	var productsPath, _ = filepath.Abs("products.jsonl")
+	_ = ioutil.WriteFile(usersPath, []byte("1\n2\n3\n"), filesMode)
+	_ = ioutil.WriteFile(productsPath, []byte("{\"sku\":\"A\"}\n{\"sku\":\"B\"}\n"), filesMode)
+	defer os.Remove(usersPath)
+	defer os.Remove(productsPath)
+
+	var sources, errOpen = OpenTemplateSources(&app.TemplateConfiguration{Sources: []*app.TemplateSourceConfiguration{
+		{Name: "users", Path: usersPath, Mode: TemplateModeOnce, Type: TemplateTypeCSV, Delimiter: DefaultTemplateDelimiter},
+		{Name: "products", Path: productsPath, Mode: TemplateModeSequential, Type: TemplateTypeJSONL},
+	}}, 1)
+	if errOpen != nil {
+		t.Fatalf("Unexpected error: %v", errOpen)
+	}
+	defer sources.Close()
+
+	var actual []string
+	for {
+		var record, hasLine, errNext = sources.Next(0)
+		if !hasLine {
+			break
+		}
+
+		if errNext != nil {
+			t.Fatalf("Unexpected error: %v", errNext)
+		}
+
+		var user, _ = record.Value("users.0")
+		var sku, _ = record.Value("products.sku")
+		actual = append(actual, user+sku)
+	}
+
+	if strings.Join(actual, ",") != "1A,2B,3A" {
+		t.Errorf("Unexpected selected lines %v", actual)
+	}
+}
+
+func TestValidateNamedDataSources(t *testing.T) {
+	var usersPath, _ = filepath.Abs("users.csv")
+	var productsPath, _ = filepath.Abs("products.jsonl")
+	_ = ioutil.WriteFile(usersPath, []byte("id,name\n1,alice\n2,bob\n"), filesMode)
+	_ = ioutil.WriteFile(productsPath, []byte("{\"sku\":\"A\"}\n{\"sku\":\"B\"}\n{\"sku\":\"C\"}\n"), filesMode)
+	defer os.Remove(usersPath)
+	defer os.Remove(productsPath)
+
+	var appConf = &app.Configuration{}
+	var getValidator = &GetValidator{}
+	var validatorEntity = getValidator.AddRequestCount(1).
+		AddThreads(2).
+		AddUrl("http://localhost:8080/users/#T{users.id}/products/#T{products.sku}").
+		AddTemplateFormat("", "", true).
+		AddDataSources([]string{"users=users.csv", "products=products.jsonl"}, []string{"users=per-thread"}).
+		WithAppConfiguration(appConf).
+		Entity()
+
+	var actualValidationResult = validatorEntity.Validate()
+
+	if !actualValidationResult.valid || len(actualValidationResult.errMessages) > 0 || len(actualValidationResult.warnMessages) > 0 {
+		t.Errorf("Unexpected validation result %v", actualValidationResult)
+	}
+
+	var users, products = templateSource(appConf.Template, "users"), templateSource(appConf.Template, "products")
+	if !appConf.Template.Enabled || users == nil || products == nil || len(appConf.Template.Sources) != 2 {
+		t.Fatalf("Unexpected app configuration result %v", appConf.Template)
+	}
+
+	if users.Path != usersPath || users.Size != 2 || users.Mode != TemplateModePerThread || !users.Header || users.Type != TemplateTypeCSV {
+		t.Errorf("Unexpected users data source %v", users)
+	}
+
+	if products.Path != productsPath || products.Size != 3 || products.Mode != TemplateModeRandom || products.Header || products.Type != TemplateTypeJSONL {
+		t.Errorf("Unexpected products data source %v", products)
+	}
+}
+
+func TestValidateNamedDataSourcesWithErrors(t *testing.T) {
+	var usersPath, _ = filepath.Abs("users.csv")
+	var productsPath, _ = filepath.Abs("products.jsonl")
+	_ = ioutil.WriteFile(usersPath, []byte("id,name\n1,alice\n2,bob\n"), filesMode)
+	_ = ioutil.WriteFile(productsPath, []byte("{\"sku\":\"A\"}\n{\"id\":2}\n"), filesMode)
+	defer os.Remove(usersPath)
+	defer os.Remove(productsPath)
+
+	var givenTestCases = []struct {
+		dataSources []string
+		dataModes   []string
+		url         string
+		expected    []string
+	}{
+		{
+			dataSources: []string{"users=users.csv", "users=products.jsonl"},
+			url:         "http://localhost:8080/users/#T{users.id}",
+			expected:    []string{"Data source name 'users' is used more than once"},
+		},
+		{
+			dataSources: []string{"users=users.csv"},
+			dataModes:   []string{"orders=once", "users"},
+			url:         "http://localhost:8080/users/#T{users.id}",
+			expected: []string{
+				"Data mode 'orders=once' refers to unknown data source 'orders'",
+				"Provided data mode 'users' is invalid. Expected format is 'name=mode'",
+			},
+		},
+		{
+			dataSources: []string{"users=users.csv", "products=products.jsonl", "orders=orders.csv"},
+			url:         "http://localhost:8080/users/#T{users.email}/products/#T{products.sku}",
+			expected:    []string{"Data source 'orders': Provided template file 'orders.csv' can not be found"},
+		},
+		{
+			dataSources: []string{"users=users.csv", "products=products.jsonl"},
+			url:         "http://localhost:8080/users/#T{users.email}/products/#T{products.sku}",
+			expected:    []string{"Data source 'users': Placeholder key 'email' doesn't match any column of template header row"},
+		},
+		{
+			dataSources: []string{"users=users.csv", "products=products.jsonl"},
+			url:         "http://localhost:8080/users/#T{users.name}/products/#T{products.sku}",
+			expected:    []string{"Data source 'products': Template line 2 is invalid. Reason: there is no value for placeholder key 'products.sku'"},
+		},
+	}
+
+	for _, testCase := range givenTestCases {
+		var appConf = &app.Configuration{}
+		var getValidator = &GetValidator{}
+		var validatorEntity = getValidator.AddRequestCount(1).
+			AddThreads(1).
+			AddUrl(testCase.url).
+			AddTemplateFormat("", "", true).
+			AddDataSources(testCase.dataSources, testCase.dataModes).
+			WithAppConfiguration(appConf).
+			Entity()
+
+		var actualValidationResult = validatorEntity.Validate()
+
+		if actualValidationResult.valid || !reflect.DeepEqual(actualValidationResult.errMessages, testCase.expected) {
+			t.Errorf("Unexpected validation result %v for data sources %v", actualValidationResult, testCase.dataSources)
+		}
+
+		if appConf.Template.Enabled {
+			t.Errorf("Unexpected app configuration result %v", appConf.Template)
+		}
+	}
+}
+
+// templateSource returns a validated template source with a given name or nil. The template file has an empty name
+func templateSource(conf *app.TemplateConfiguration, name string) *app.TemplateSourceConfiguration {
+	for _, source := range conf.Sources {
+		if source.Name == name {
+			return source
+		}
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+package util
+
+import (
+	"fmt"
+	"github.com/vkrava4/curlson/faker"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxRandStringLength is a maximum length of a string generated by 'randString' function
+const maxRandStringLength = 1024 * 1024
+
+// maxLoremWords is a maximum number of words generated by 'lorem' function
+const maxLoremWords = 10000
+
+// defaultLoremWords is a number of words generated by 'lorem' function when it's not specified
+const defaultLoremWords = 10
+
+var (
+	// templateFunctionRegex matches `#F{name}` and `#F{name:arg1:arg2}` template functions
+	templateFunctionRegex = regexp.MustCompile(`#F\{([^{}]*)\}`)
+
+	nowFormats = map[string]func(now time.Time) string{
+		"":         func(now time.Time) string { return now.Format(time.RFC3339) },
+		"rfc3339":  func(now time.Time) string { return now.Format(time.RFC3339) },
+		"unix":     func(now time.Time) string { return strconv.FormatInt(now.Unix(), 10) },
+		"unixms":   func(now time.Time) string { return strconv.FormatInt(now.UnixNano()/int64(time.Millisecond), 10) },
+		"unixnano": func(now time.Time) string { return strconv.FormatInt(now.UnixNano(), 10) },
+	}
+
+	// templateFunctions are all known template functions by their names
+	templateFunctions = map[string]templateFunctionSpec{
+		"uuid":       fakerFunction(func(f *faker.Faker) string { return f.UUID() }),
+		"randInt":    {minArgs: 2, maxArgs: 2, evaluate: evaluateRandInt},
+		"randString": {minArgs: 1, maxArgs: 1, evaluate: evaluateRandString},
+		"now":        {minArgs: 0, maxArgs: 1, evaluate: evaluateNow},
+		"seq":        contextFunction(func(context *FunctionContext) string { return strconv.FormatInt(context.Sequence, 10) }),
+		"threadId":   contextFunction(func(context *FunctionContext) string { return strconv.Itoa(context.ThreadID) }),
+		"iteration":  contextFunction(func(context *FunctionContext) string { return strconv.Itoa(context.Iteration) }),
+		"env":        {minArgs: 1, maxArgs: 1, evaluate: evaluateEnv},
+
+		"firstName": fakerFunction(func(f *faker.Faker) string { return f.FirstName() }),
+		"lastName":  fakerFunction(func(f *faker.Faker) string { return f.LastName() }),
+		"name":      fakerFunction(func(f *faker.Faker) string { return f.Name() }),
+		"email":     fakerFunction(func(f *faker.Faker) string { return f.Email() }),
+		"phone":     fakerFunction(func(f *faker.Faker) string { return f.Phone() }),
+		"street":    fakerFunction(func(f *faker.Faker) string { return f.Street() }),
+		"city":      fakerFunction(func(f *faker.Faker) string { return f.City() }),
+		"country":   fakerFunction(func(f *faker.Faker) string { return f.Country() }),
+		"zip":       fakerFunction(func(f *faker.Faker) string { return f.ZipCode() }),
+		"address":   fakerFunction(func(f *faker.Faker) string { return f.Address() }),
+		"iban":      fakerFunction(func(f *faker.Faker) string { return f.IBAN() }),
+		"ipv4":      fakerFunction(func(f *faker.Faker) string { return f.IPv4() }),
+		"ipv6":      fakerFunction(func(f *faker.Faker) string { return f.IPv6() }),
+		"userAgent": fakerFunction(func(f *faker.Faker) string { return f.UserAgent() }),
+		"word":      fakerFunction(func(f *faker.Faker) string { return f.Word() }),
+		"lorem":     {minArgs: 0, maxArgs: 1, evaluate: evaluateLorem},
+		"date":      {minArgs: 2, maxArgs: 2, evaluate: evaluateDate},
+	}
+)
+
+// FunctionContext holds request-scoped values of template functions. The same values are used
+// for every occurrence of `#F{seq}`, `#F{threadId}` and `#F{iteration}` within a single request.
+// Random and fake values are generated with Faker, so they are reproducible for the same seed and sequence number
+type FunctionContext struct {
+	ThreadID  int
+	Iteration int
+	Sequence  int64
+	Faker     *faker.Faker
+}
+
+// templateFunction is a parsed template function call
+type templateFunction struct {
+	name string
+	args []string
+	spec templateFunctionSpec
+}
+
+// templateFunctionSpec describes a number of arguments of a template function and how it's evaluated
+type templateFunctionSpec struct {
+	minArgs  int
+	maxArgs  int
+	evaluate func(args []string, context *FunctionContext) (string, error)
+}
+
+// fakerFunction creates a specification of a template function without arguments generating values with Faker
+func fakerFunction(generate func(f *faker.Faker) string) templateFunctionSpec {
+	return templateFunctionSpec{evaluate: func(args []string, context *FunctionContext) (string, error) {
+		return generate(context.faker()), nil
+	}}
+}
+
+// contextFunction creates a specification of a template function without arguments returning a request-scoped value
+func contextFunction(value func(context *FunctionContext) string) templateFunctionSpec {
+	return templateFunctionSpec{evaluate: func(args []string, context *FunctionContext) (string, error) {
+		return value(context), nil
+	}}
+}
+
+// faker returns Faker of the context creating a seeded one when it's not set
+func (c *FunctionContext) faker() *faker.Faker {
+	if c.Faker == nil {
+		c.Faker = faker.NewForSequence(0, c.Sequence)
+	}
+	return c.Faker
+}
+
+// ResolveFunctions replaces template functions of a given text with generated values:
+//
+//	#F{uuid} - a random UUID (version 4)
+//	#F{randInt:min:max} - a random integer in range [min, max]
+//	#F{randString:length} - a random alphanumeric string of a given length
+//	#F{now} or #F{now:format} - current time in 'rfc3339' (default), 'unix', 'unixms' or 'unixnano' format
+//	#F{seq} - a global sequence number of a request shared by all threads
+//	#F{threadId} - an id of a thread performing a request
+//	#F{iteration} - a number of a request within its thread
+//	#F{env:NAME} - a value of an environment variable
+//	#F{firstName}, #F{lastName}, #F{name}, #F{email}, #F{phone} - fake personal data
+//	#F{street}, #F{city}, #F{country}, #F{zip}, #F{address} - fake address data
+//	#F{iban}, #F{ipv4}, #F{ipv6}, #F{userAgent} - fake IBAN with valid check digits, IP addresses and browser user agent
+//	#F{word} or #F{lorem:words} - lorem ipsum word or a given number of words (10 by default)
+//	#F{date:from:to} - a random date in range [from, to] given as YYYY-MM-DD
+//
+// Random values are generated for every occurrence. Malformed functions are left untouched, see ValidateFunctions
+func ResolveFunctions(text string, context *FunctionContext) string {
+	if !strings.Contains(text, "#F{") {
+		return text
+	}
+
+	return templateFunctionRegex.ReplaceAllStringFunc(text, func(call string) string {
+		var function, errParse = parseTemplateFunction(call)
+		if errParse != nil {
+			return call
+		}
+
+		var value, errEvaluate = function.evaluate(context)
+		if errEvaluate != nil {
+			return call
+		}
+		return value
+	})
+}
+
+// ValidateFunctions checks whether all template functions of a given text are known and have valid arguments
+func ValidateFunctions(text string) error {
+	for _, call := range templateFunctionRegex.FindAllString(text, -1) {
+		var function, errParse = parseTemplateFunction(call)
+		if errParse == nil {
+			_, errParse = function.evaluate(&FunctionContext{})
+		}
+
+		if errParse != nil {
+			return fmt.Errorf("Template function '%s' is invalid. Reason: %s", call, errParse.Error())
+		}
+	}
+	return nil
+}
+
+// ContainsFunctions returns a boolean indicating whether a given text contains template functions
+func ContainsFunctions(text string) bool {
+	return templateFunctionRegex.MatchString(text)
+}
+
+func parseTemplateFunction(call string) (*templateFunction, error) {
+	var parts = strings.Split(templateFunctionRegex.FindStringSubmatch(call)[1], ":")
+	var spec, exists = templateFunctions[parts[0]]
+	if !exists {
+		return nil, fmt.Errorf("unknown function '%s'", parts[0])
+	}
+
+	var function = &templateFunction{name: parts[0], args: parts[1:], spec: spec}
+	if len(function.args) < spec.minArgs || len(function.args) > spec.maxArgs {
+		return nil, fmt.Errorf("function '%s' expects %d to %d argument(s), but got %d", function.name, spec.minArgs, spec.maxArgs, len(function.args))
+	}
+
+	return function, nil
+}
+
+func (f *templateFunction) evaluate(context *FunctionContext) (string, error) {
+	return f.spec.evaluate(f.args, context)
+}
+
+func evaluateRandInt(args []string, context *FunctionContext) (string, error) {
+	var min, errMin = strconv.ParseInt(args[0], 10, 64)
+	var max, errMax = strconv.ParseInt(args[1], 10, 64)
+	if errMin != nil || errMax != nil || min > max {
+		return "", fmt.Errorf("'%s' and '%s' should be integers and the first should not exceed the second", args[0], args[1])
+	}
+	return strconv.FormatInt(context.faker().Int(min, max), 10), nil
+}
+
+func evaluateRandString(args []string, context *FunctionContext) (string, error) {
+	var length, errLength = strconv.Atoi(args[0])
+	if errLength != nil || length < 1 || length > maxRandStringLength {
+		return "", fmt.Errorf("'%s' should be an integer between 1 and %d", args[0], maxRandStringLength)
+	}
+	return context.faker().String(length), nil
+}
+
+func evaluateNow(args []string, context *FunctionContext) (string, error) {
+	var format = ""
+	if len(args) > 0 {
+		format = args[0]
+	}
+
+	var formatNow, exists = nowFormats[format]
+	if !exists {
+		return "", fmt.Errorf("'%s' should be one of: rfc3339, unix, unixms, unixnano", format)
+	}
+	return formatNow(time.Now()), nil
+}
+
+func evaluateEnv(args []string, context *FunctionContext) (string, error) {
+	var value, exists = os.LookupEnv(args[0])
+	if !exists {
+		return "", fmt.Errorf("environment variable '%s' is not set", args[0])
+	}
+	return value, nil
+}
+
+func evaluateLorem(args []string, context *FunctionContext) (string, error) {
+	var words = defaultLoremWords
+	if len(args) > 0 {
+		var errWords error
+		words, errWords = strconv.Atoi(args[0])
+		if errWords != nil || words < 1 || words > maxLoremWords {
+			return "", fmt.Errorf("'%s' should be an integer between 1 and %d", args[0], maxLoremWords)
+		}
+	}
+	return context.faker().Lorem(words), nil
+}
+
+func evaluateDate(args []string, context *FunctionContext) (string, error) {
+	var from, errFrom = time.Parse(faker.DateLayout, args[0])
+	var to, errTo = time.Parse(faker.DateLayout, args[1])
+	if errFrom != nil || errTo != nil || from.After(to) {
+		return "", fmt.Errorf("'%s' and '%s' should be dates in format YYYY-MM-DD and the first should not be after the second", args[0], args[1])
+	}
+	return context.faker().Date(from, to).Format(faker.DateLayout), nil
+}
@@ -0,0 +1,81 @@
+package util
+
+import (
+	"github.com/vkrava4/curlson/faker"
+	"os"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestResolveFunctions(t *testing.T) {
+	_ = os.Setenv("CURLSON_TEST_TENANT", "acme")
+	defer os.Unsetenv("CURLSON_TEST_TENANT")
+
+	var givenContext = &FunctionContext{ThreadID: 3, Iteration: 7, Sequence: 42}
+	var actual = ResolveFunctions("/#F{env:CURLSON_TEST_TENANT}/#F{threadId}/#F{iteration}/#F{seq}/#F{seq}", givenContext)
+
+	var expected = "/acme/3/7/42/42"
+	if actual != expected {
+		t.Errorf("ResolveFunctions result is incorrect, actual: '%s', expected: '%s'", actual, expected)
+	}
+}
+
+func TestResolveRandomFunctions(t *testing.T) {
+	var expectations = map[string]*regexp.Regexp{
+		"#F{uuid}":          regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`),
+		"#F{randString:12}": regexp.MustCompile(`^[a-zA-Z0-9]{12}$`),
+		"#F{now}":           regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T`),
+		"#F{now:unixms}":    regexp.MustCompile(`^\d{13}$`),
+	}
+
+	for given, expected := range expectations {
+		if actual := ResolveFunctions(given, &FunctionContext{}); !expected.MatchString(actual) {
+			t.Errorf("Unexpected value of '%s': '%s'", given, actual)
+		}
+	}
+
+	for i := 0; i < 100; i++ {
+		var actual, errParse = strconv.Atoi(ResolveFunctions("#F{randInt:-2:2}", &FunctionContext{}))
+		if errParse != nil || actual < -2 || actual > 2 {
+			t.Fatalf("Unexpected value of random integer: %d, %v", actual, errParse)
+		}
+	}
+}
+
+func TestValidateFunctions(t *testing.T) {
+	_ = os.Unsetenv("CURLSON_TEST_MISSING")
+
+	var invalid = []string{
+		"#F{unknown}",
+		"#F{uuid:1}",
+		"#F{randInt:1}",
+		"#F{randInt:10:1}",
+		"#F{randInt:a:b}",
+		"#F{randString:0}",
+		"#F{now:yesterday}",
+		"#F{env:CURLSON_TEST_MISSING}",
+		"#F{}",
+	}
+
+	for _, given := range invalid {
+		if errValidate := ValidateFunctions("http://localhost/" + given); errValidate == nil {
+			t.Errorf("An error is expected for '%s'", given)
+		}
+	}
+
+	if errValidate := ValidateFunctions("#F{uuid} #F{randInt:1:1000} #F{now:unix} #F{seq}"); errValidate != nil {
+		t.Errorf("Unexpected error: %v", errValidate)
+	}
+}
+
+func TestResolveFakerFunctionsWithSeed(t *testing.T) {
+	var givenText = `{"name":"#F{name}","email":"#F{email}","iban":"#F{iban}","born":"#F{date:1970-01-01:2000-12-31}","id":"#F{uuid}","n":#F{randInt:1:1000}}`
+
+	var first = ResolveFunctions(givenText, &FunctionContext{Sequence: 5, Faker: faker.NewForSequence(42, 5)})
+	var second = ResolveFunctions(givenText, &FunctionContext{Sequence: 5, Faker: faker.NewForSequence(42, 5)})
+
+	if first != second || ContainsFunctions(first) {
+		t.Errorf("Values resolved with the same seed should be equal, actual: '%s' and '%s'", first, second)
+	}
+}
@@ -1,12 +1,11 @@
 package util
 
 import (
-	"bufio"
 	"fmt"
 	"github.com/vkrava4/curlson/app"
-	"io"
-	"os"
-	"path/filepath"
+	"github.com/vkrava4/curlson/load"
+	"github.com/vkrava4/curlson/stats"
+	"math"
 	"strings"
 )
 
@@ -17,6 +16,20 @@ type GetValidatorBuilder interface {
 	AddRequestCount(requestCount int) GetValidatorBuilder
 	AddSleep(sleep int) GetValidatorBuilder
 	AddMaxDuration(sleep int) GetValidatorBuilder
+	AddOutput(output string, format string) GetValidatorBuilder
+	AddThresholds(thresholds []string) GetValidatorBuilder
+	AddHeaders(headers []string, headersFile string) GetValidatorBuilder
+	AddTemplateMode(mode string, seed int64) GetValidatorBuilder
+	AddTemplateFormat(templateType string, delimiter string, header bool) GetValidatorBuilder
+	AddTemplateEngine(engine string) GetValidatorBuilder
+	AddDataSources(sources []string, modes []string) GetValidatorBuilder
+	AddRate(rate string) GetValidatorBuilder
+	AddStages(stages []string, stageType string) GetValidatorBuilder
+	AddDuration(duration string) GetValidatorBuilder
+	AddThinkTime(thinkTime string) GetValidatorBuilder
+	AddMaxRate(maxRate string, burst int, controlAddress string) GetValidatorBuilder
+	AddTimeouts(connect string, tls string, responseHeader string, maxTime string) GetValidatorBuilder
+	AddTransport(keepAlive bool, pool string, maxConnectionsPerHost int, idleTimeout string, newConnectionPerRequest bool) GetValidatorBuilder
 
 	WithAppConfiguration(conf *app.Configuration) GetValidatorBuilder
 
@@ -57,6 +70,92 @@ func (b *GetValidator) AddMaxDuration(maxDuration int) GetValidatorBuilder {
 	return b
 }
 
+func (b *GetValidator) AddOutput(output string, format string) GetValidatorBuilder {
+	b.entity.output = output
+	b.entity.outputFormat = format
+	return b
+}
+
+func (b *GetValidator) AddThresholds(thresholds []string) GetValidatorBuilder {
+	b.entity.thresholds = thresholds
+	return b
+}
+
+func (b *GetValidator) AddHeaders(headers []string, headersFile string) GetValidatorBuilder {
+	b.entity.headers = headers
+	b.entity.headersFile = headersFile
+	return b
+}
+
+func (b *GetValidator) AddTemplateMode(mode string, seed int64) GetValidatorBuilder {
+	b.entity.templateMode = mode
+	b.entity.seed = seed
+	return b
+}
+
+func (b *GetValidator) AddTemplateFormat(templateType string, delimiter string, header bool) GetValidatorBuilder {
+	b.entity.templateType = templateType
+	b.entity.templateDelimiter = delimiter
+	b.entity.templateHeader = header
+	return b
+}
+
+func (b *GetValidator) AddTemplateEngine(engine string) GetValidatorBuilder {
+	b.entity.templateEngine = engine
+	return b
+}
+
+func (b *GetValidator) AddDataSources(sources []string, modes []string) GetValidatorBuilder {
+	b.entity.dataSources = sources
+	b.entity.dataModes = modes
+	return b
+}
+
+func (b *GetValidator) AddRate(rate string) GetValidatorBuilder {
+	b.entity.rate = rate
+	return b
+}
+
+func (b *GetValidator) AddStages(stages []string, stageType string) GetValidatorBuilder {
+	b.entity.stages = stages
+	b.entity.stageType = stageType
+	return b
+}
+
+func (b *GetValidator) AddDuration(duration string) GetValidatorBuilder {
+	b.entity.duration = duration
+	return b
+}
+
+func (b *GetValidator) AddThinkTime(thinkTime string) GetValidatorBuilder {
+	b.entity.thinkTime = thinkTime
+	return b
+}
+
+func (b *GetValidator) AddMaxRate(maxRate string, burst int, controlAddress string) GetValidatorBuilder {
+	b.entity.maxRate = maxRate
+	b.entity.maxRateBurst = burst
+	b.entity.controlAddr = controlAddress
+	return b
+}
+
+func (b *GetValidator) AddTimeouts(connect string, tls string, responseHeader string, maxTime string) GetValidatorBuilder {
+	b.entity.connectTimeout = connect
+	b.entity.tlsTimeout = tls
+	b.entity.responseHeaderTimeout = responseHeader
+	b.entity.maxTime = maxTime
+	return b
+}
+
+func (b *GetValidator) AddTransport(keepAlive bool, pool string, maxConnectionsPerHost int, idleTimeout string, newConnectionPerRequest bool) GetValidatorBuilder {
+	b.entity.keepAlive = keepAlive
+	b.entity.connectionPool = pool
+	b.entity.maxConnectionsPerHost = maxConnectionsPerHost
+	b.entity.idleTimeout = idleTimeout
+	b.entity.newConnectionPerRequest = newConnectionPerRequest
+	return b
+}
+
 func (b *GetValidator) WithAppConfiguration(conf *app.Configuration) GetValidatorBuilder {
 	if conf.Template == nil {
 		conf.Template = &app.TemplateConfiguration{}
@@ -66,6 +165,14 @@ func (b *GetValidator) WithAppConfiguration(conf *app.Configuration) GetValidato
 		conf.Logs = &app.LogConfiguration{}
 	}
 
+	if conf.Request == nil {
+		conf.Request = &app.RequestConfiguration{}
+	}
+
+	if conf.Load == nil {
+		conf.Load = &app.LoadConfiguration{}
+	}
+
 	b.entity.conf = conf
 	return b
 }
@@ -84,12 +191,73 @@ func (e *ValidatorEntity) Validate() *ValidationResult {
 	validatePositive("Amount of requests per thread", e.requestCount, result)
 	validatePositiveOrZero("Delay in millis property", e.sleep, result)
 	validatePositiveOrZero("Maximum execution duration property", e.maxDuration, result)
+	validateRate(e.rate, e.sleep, result)
+	var profile = validateStages(e.stages, e.stageType, e.rate, e.sleep, e.requestCount, result)
+	validateDuration(e.duration, e.requestCount, len(e.stages) > 0, result)
+	validateThinkTime(e.thinkTime, e.sleep, e.rate != "" || len(e.stages) > 0 && e.stageType == load.StageTypeRate, e.template != "", result)
+	validateMaxRate(e.maxRate, e.maxRateBurst, e.controlAddr, len(e.stages) > 0 && e.stageType == load.StageTypeMaxRate, result)
+
+	if e.output != "" {
+		validateOneOf("Output format", e.outputFormat, outputFormats, result)
+	}
+
+	validateThresholds(e.thresholds, result)
+	validateTimeouts(e.connectTimeout, e.tlsTimeout, e.responseHeaderTimeout, e.maxTime, result)
+	validateTransport(e.keepAlive, e.connectionPool, e.maxConnectionsPerHost, e.idleTimeout, e.newConnectionPerRequest, result)
+	validateMethod(e.method, result)
+	validateBody(e.body, e.contentType, result)
+	var headers = validateHeaders(e.headers, e.headersFile, result)
+	var body []byte
+	if result.conf != nil {
+		body = result.conf.Request.Body
+	}
 
-	validateUrlForTemplate(e.template, e.url, result)
+	var request = validateTemplateEngine(e.templateEngine, &templatedRequest{url: e.url, headers: headers, body: body, bodyTemplated: true}, result)
+	var sources = validateDataSources(e.template, e.templateMode, e.dataSources, e.dataModes, result)
+	var format = validateTemplateFormat(e.templateType, e.templateDelimiter, e.templateHeader, result)
+	if request != nil {
+		validateUrlForTemplate(sources, format, request, result)
+	}
+	// with a rate the request count is a total number of requests sent by all threads
+	var threads, totalRequests = e.threads, e.threads * e.requestCount
+	switch {
+	case profile != nil && e.stageType == load.StageTypeThreads:
+		// a number of requests of a threads profile is unknown in advance
+		threads, totalRequests = int(math.Ceil(profile.MaxTarget())), 0
+	case profile != nil && e.stageType == load.StageTypeRate:
+		totalRequests = profile.Jobs()
+	case profile != nil:
+		// a number of requests of a max rate profile is unknown in advance
+		totalRequests = 0
+	case e.duration != "":
+		// a number of requests of a run limited by a duration is unknown in advance
+		totalRequests = 0
+	case e.rate != "":
+		totalRequests = e.requestCount
+	}
+	validateTemplateMode(e.seed, threads, totalRequests, result)
 
 	return result
 }
 
+func validateThresholds(thresholds []string, result *ValidationResult) {
+	var parsedThresholds []*stats.Threshold
+	for _, expression := range thresholds {
+		var threshold, errParse = stats.ParseThreshold(expression)
+		if errParse != nil {
+			result.valid = false
+			result.errMessages = append(result.errMessages, fmt.Sprintf(MsgThresholdInvalidWithReason, expression, errParse.Error()))
+			continue
+		}
+
+		parsedThresholds = append(parsedThresholds, threshold)
+	}
+
+	if result.valid && result.conf != nil {
+		result.conf.Thresholds = parsedThresholds
+	}
+}
+
 func validatePositive(description string, value int, result *ValidationResult) {
 	if value < 1 {
 		result.valid = false
@@ -104,87 +272,13 @@ func validatePositiveOrZero(description string, value int, result *ValidationRes
 	}
 }
 
-func validateEmptyTemplate(template string, urlAddress string, result *ValidationResult) bool {
-	if template == "" {
-		var _, errPrepareUrl = PrepareUrl(urlAddress, "")
-		if errPrepareUrl != nil {
-			result.valid = false
-			result.errMessages = append(result.errMessages, fmt.Sprintf(MsgURLAddressInvalidWithReason, urlAddress, errPrepareUrl.Error()))
-		}
-		return true
-	}
-
-	return false
-}
-
-func validateUrlForTemplate(template string, urlAddress string, result *ValidationResult) {
-
-	if !validateEmptyTemplate(template, urlAddress, result) {
-		var absTemplatePath, errAbsFile = filepath.Abs(template)
-		if errAbsFile != nil {
-			result.valid = false
-			result.errMessages = append(result.errMessages, fmt.Sprintf(MsgTemplatePathInvalidWithReason, template, errAbsFile.Error()))
+func validateOneOf(description string, value string, allowed []string, result *ValidationResult) {
+	for _, allowedValue := range allowed {
+		if value == allowedValue {
 			return
 		}
-
-		if fileExist(absTemplatePath) {
-			var templateFile, errOpenFile = os.OpenFile(template, os.O_RDONLY, filesMode)
-			if errOpenFile != nil {
-				result.valid = false
-				result.errMessages = append(result.errMessages, fmt.Sprintf(MsgCantOpenTemplateWithReason, template, errOpenFile.Error()))
-			} else {
-				var templateSize, errValidateURL = validateURLForExistingTemplate(templateFile, urlAddress, result)
-				_ = templateFile.Close()
-
-				if errValidateURL != nil {
-					result.valid = false
-					result.errMessages = append(result.errMessages, errValidateURL.Error())
-				}
-
-				if templateSize > 0 && result.valid && result.conf != nil {
-					result.conf.Template.Enabled = true
-					result.conf.Template.Path = absTemplatePath
-					result.conf.Template.Size = templateSize
-
-				}
-			}
-		} else {
-			result.valid = false
-			result.errMessages = append(result.errMessages, fmt.Sprintf(MsgTemplateNotFound, template))
-		}
 	}
-}
-
-func validateURLForExistingTemplate(templateFile *os.File, urlAddress string, result *ValidationResult) (int, error) {
-	var templateSize = 0
-	if !ContainsTemplatePlaceholders(urlAddress) {
-		result.warnMessages = append(result.warnMessages, fmt.Sprintf(MsgURLPlaceholdersNotFound, urlAddress))
-	} else {
-		var reader = bufio.NewReader(templateFile)
-		for {
-			var line, errReadLine = reader.ReadString(filesEndLineDelimiter)
-
-			switch {
-			case errReadLine == io.EOF:
-				break
-
-			case errReadLine != nil:
-				return -1, errReadLine
-			}
 
-			if len(line) == 0 {
-				break
-			} else {
-				line = strings.TrimSuffix(line, string(filesEndLineDelimiter))
-				var _, errPrepareUrl = PrepareUrl(urlAddress, line)
-
-				if errPrepareUrl != nil {
-					return -1, errPrepareUrl
-				}
-
-				templateSize++
-			}
-		}
-	}
-	return templateSize, nil
+	result.valid = false
+	result.errMessages = append(result.errMessages, fmt.Sprintf(MsgShouldBeOneOf, description, strings.Join(allowed, ", "), value))
 }
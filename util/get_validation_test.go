@@ -362,7 +362,7 @@ func TestValidateExistingTemplateAndUrlWithPlaceholders_WithOkOtherFlags(t *test
 		t.Errorf("Unexpected validation result %v", actualValidationResult)
 	}
 
-	if !appConf.Template.Enabled || appConf.Template.Size != 1 || appConf.Template.Path != testFileAbsPath {
+	if !appConf.Template.Enabled || templateSource(appConf.Template, "").Size != 1 || templateSource(appConf.Template, "").Path != testFileAbsPath {
 		t.Errorf("Unexpected app configuration result %v", actualValidationResult)
 	}
 }
@@ -400,9 +400,72 @@ func TestValidateExistingTemplateAndUrlWithPlaceholders_WithOkOtherFlags_ForLarg
 		t.Errorf("Unexpected validation result %v", actualValidationResult)
 	}
 
-	if !appConf.Template.Enabled || appConf.Template.Size != givenNumberOfRecords || appConf.Template.Path != testFileAbsPath {
+	if !appConf.Template.Enabled || templateSource(appConf.Template, "").Size != givenNumberOfRecords || templateSource(appConf.Template, "").Path != testFileAbsPath {
 		t.Errorf("Unexpected app configuration result %v", actualValidationResult)
 	}
 
 	t.Logf("For %d items template items validation took %d ms", givenNumberOfRecords, time.Now().Sub(start).Milliseconds())
 }
+
+func TestValidateOutputFormatWithOkOtherFlags(t *testing.T) {
+	var givenUrl = "http://localhost:8080"
+	var givenOutput = "summary.json"
+	var givenInvalidFormat = "xml"
+
+	var getValidator = &GetValidator{}
+	var validatorEntity = getValidator.AddRequestCount(1).
+		AddThreads(1).
+		AddUrl(givenUrl).
+		AddOutput(givenOutput, givenInvalidFormat).
+		Entity()
+
+	var actualValidationResult = validatorEntity.Validate()
+
+	if actualValidationResult.valid || strings.Join(actualValidationResult.errMessages, ",") !=
+		fmt.Sprintf(MsgShouldBeOneOf, "Output format", "json, csv", givenInvalidFormat) {
+		t.Errorf("Unexpected validation result %v", actualValidationResult)
+	}
+}
+
+func TestValidateThresholdsWithOkOtherFlags(t *testing.T) {
+	var givenUrl = "http://localhost:8080"
+	var givenThresholds = []string{"p95<300ms", "error_rate<1%"}
+
+	var getValidator = &GetValidator{}
+	var appConf = &app.Configuration{}
+	var validatorEntity = getValidator.AddRequestCount(1).
+		AddThreads(1).
+		AddUrl(givenUrl).
+		AddThresholds(givenThresholds).
+		WithAppConfiguration(appConf).
+		Entity()
+
+	var actualValidationResult = validatorEntity.Validate()
+
+	if !actualValidationResult.valid || len(actualValidationResult.errMessages) > 0 {
+		t.Errorf("Unexpected validation result %v", actualValidationResult)
+	}
+
+	if len(appConf.Thresholds) != 2 || appConf.Thresholds[1].Metric != "error_rate" {
+		t.Errorf("Unexpected app configuration thresholds %v", appConf.Thresholds)
+	}
+}
+
+func TestValidateInvalidThresholdWithOkOtherFlags(t *testing.T) {
+	var givenUrl = "http://localhost:8080"
+	var givenInvalidThreshold = "p95<fast"
+
+	var getValidator = &GetValidator{}
+	var validatorEntity = getValidator.AddRequestCount(1).
+		AddThreads(1).
+		AddUrl(givenUrl).
+		AddThresholds([]string{givenInvalidThreshold}).
+		Entity()
+
+	var actualValidationResult = validatorEntity.Validate()
+
+	if actualValidationResult.valid || len(actualValidationResult.errMessages) != 1 ||
+		!strings.HasPrefix(actualValidationResult.errMessages[0], fmt.Sprintf("Provided threshold '%s' is invalid", givenInvalidThreshold)) {
+		t.Errorf("Unexpected validation result %v", actualValidationResult)
+	}
+}
@@ -0,0 +1,209 @@
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/vkrava4/curlson/app"
+	"strings"
+	"text/template"
+)
+
+const (
+	goTemplateURL  = "url"
+	goTemplateBody = "body"
+)
+
+// GoTemplateData is a data context of Go templates. Row is a current template record: a map of CSV values
+// by their column names and indexes or a decoded JSON value. Vars are variables of a thread (virtual user)
+// which are kept between its requests, see 'set' and 'get' template functions
+type GoTemplateData struct {
+	Row       interface{}
+	ThreadID  int
+	Iteration int
+	Seq       int64
+	Vars      map[string]interface{}
+}
+
+// CompileGoTemplate parses URL, header values and body as Go templates of a single set. Along with Go built-in
+// functions, templates can use every '#F{...}' template function, e.g. '{{uuid}}' or '{{randInt 1 100}}', and helpers:
+// 'toJson' (encodes a value as JSON), 'jsonEscape', 'upper', 'lower', 'trim', 'set' and 'get' (thread variables)
+func CompileGoTemplate(url string, headers []app.Header, body []byte) (*template.Template, error) {
+	var goTemplate = template.New(goTemplateURL).Option("missingkey=error").Funcs(newGoThreadState().funcs())
+	if _, errParse := goTemplate.Parse(url); errParse != nil {
+		return nil, errParse
+	}
+
+	for i, header := range headers {
+		if _, errParse := goTemplate.New(goHeaderTemplateName(i, header)).Parse(header.Value); errParse != nil {
+			return nil, errParse
+		}
+	}
+
+	if body != nil {
+		if _, errParse := goTemplate.New(goTemplateBody).Parse(string(body)); errParse != nil {
+			return nil, errParse
+		}
+	}
+
+	return goTemplate, nil
+}
+
+func goHeaderTemplateName(index int, header app.Header) string {
+	return fmt.Sprintf("header[%d] %s", index, header.Name)
+}
+
+// goTemplateRenderer renders requests with compiled Go templates
+type goTemplateRenderer struct {
+	url      string
+	template *template.Template
+	headers  []app.Header
+	hasBody  bool
+
+	// state is set for renderers of threads only
+	state *goThreadState
+}
+
+func newGoTemplateRenderer(url string, goTemplate *template.Template, headers []app.Header, hasBody bool) *goTemplateRenderer {
+	return &goTemplateRenderer{url: url, template: goTemplate, headers: headers, hasBody: hasBody}
+}
+
+// ForThread returns a renderer with a copy of templates which functions are bound to a state of a single thread
+func (r *goTemplateRenderer) ForThread() RequestRenderer {
+	var state = newGoThreadState()
+	var threadTemplate = template.Must(r.template.Clone())
+	threadTemplate.Funcs(state.funcs())
+
+	return &goTemplateRenderer{url: r.url, template: threadTemplate, headers: r.headers, hasBody: r.hasBody, state: state}
+}
+
+// Render executes URL, header values and body templates. It should be called on a renderer returned by ForThread
+func (r *goTemplateRenderer) Render(record TemplateRecord, context *FunctionContext) (*RenderedRequest, error) {
+	if context == nil {
+		context = &FunctionContext{}
+	}
+	r.state.context = context
+
+	var data = &GoTemplateData{
+		ThreadID:  context.ThreadID,
+		Iteration: context.Iteration,
+		Seq:       context.Sequence,
+		Vars:      r.state.vars,
+	}
+	if record != nil {
+		data.Row = record.Data()
+	}
+
+	var buffer = &bytes.Buffer{}
+	if errExecute := r.template.ExecuteTemplate(buffer, goTemplateURL, data); errExecute != nil {
+		return nil, errExecute
+	}
+
+	var requestUrl, errUrl = ParseAndValidateUrl(buffer.String())
+	if errUrl != nil {
+		return nil, errUrl
+	}
+
+	var rendered = &RenderedRequest{URL: requestUrl, Headers: make([]app.Header, 0, len(r.headers))}
+	for i, header := range r.headers {
+		buffer.Reset()
+		if errExecute := r.template.ExecuteTemplate(buffer, goHeaderTemplateName(i, header), data); errExecute != nil {
+			return nil, errExecute
+		}
+		rendered.Headers = append(rendered.Headers, app.Header{Name: header.Name, Value: buffer.String()})
+	}
+
+	if r.hasBody {
+		var body = &bytes.Buffer{}
+		if errExecute := r.template.ExecuteTemplate(body, goTemplateBody, data); errExecute != nil {
+			return nil, errExecute
+		}
+		rendered.Body = body.Bytes()
+	}
+
+	return rendered, nil
+}
+
+func (r *goTemplateRenderer) templateURL() string {
+	return r.url
+}
+
+// containsTemplatePlaceholders returns a boolean indicating whether any of templates refers to a template record
+func (r *goTemplateRenderer) containsTemplatePlaceholders() bool {
+	for _, goTemplate := range r.template.Templates() {
+		if goTemplate.Tree != nil && strings.Contains(goTemplate.Tree.Root.String(), ".Row") {
+			return true
+		}
+	}
+	return false
+}
+
+// placeholderKeys returns no keys as fields of Go templates are resolved by rendering only
+func (r *goTemplateRenderer) placeholderKeys() []string {
+	return nil
+}
+
+// recordValidator renders every record with a single thread renderer, since cloning templates per record is expensive
+func (r *goTemplateRenderer) recordValidator() func(record TemplateRecord) error {
+	var threadRenderer = r.ForThread()
+	return func(record TemplateRecord) error {
+		var _, errRender = threadRenderer.Render(record, nil)
+		return errRender
+	}
+}
+
+func (r *goTemplateRenderer) validateWithoutRecord(result *ValidationResult) {
+	if _, errRender := r.ForThread().Render(nil, nil); errRender != nil {
+		result.valid = false
+		result.errMessages = append(result.errMessages, fmt.Sprintf(MsgGoTemplateInvalidWithReason, errRender.Error()))
+	}
+}
+
+// goThreadState holds a function context of a current request and variables of a single thread
+type goThreadState struct {
+	context *FunctionContext
+	vars    map[string]interface{}
+}
+
+func newGoThreadState() *goThreadState {
+	return &goThreadState{context: &FunctionContext{}, vars: make(map[string]interface{})}
+}
+
+// funcs returns template functions bound to the state
+func (s *goThreadState) funcs() template.FuncMap {
+	var funcs = template.FuncMap{
+		"toJson": func(value interface{}) (string, error) {
+			var encoded, errEncode = json.Marshal(value)
+			return string(encoded), errEncode
+		},
+		"jsonEscape": func(value interface{}) string { return JSONEscape(fmt.Sprint(value)) },
+		"upper":      strings.ToUpper,
+		"lower":      strings.ToLower,
+		"trim":       strings.TrimSpace,
+		"set": func(name string, value interface{}) string {
+			s.vars[name] = value
+			return ""
+		},
+		"get": func(name string) interface{} { return s.vars[name] },
+	}
+
+	for name, spec := range templateFunctions {
+		funcs[name] = s.templateFunction(name, spec)
+	}
+	return funcs
+}
+
+// templateFunction adapts a '#F{...}' template function to Go templates
+func (s *goThreadState) templateFunction(name string, spec templateFunctionSpec) func(args ...interface{}) (string, error) {
+	return func(args ...interface{}) (string, error) {
+		if len(args) < spec.minArgs || len(args) > spec.maxArgs {
+			return "", fmt.Errorf("function '%s' expects %d to %d argument(s), but got %d", name, spec.minArgs, spec.maxArgs, len(args))
+		}
+
+		var stringArgs = make([]string, len(args))
+		for i, arg := range args {
+			stringArgs[i] = fmt.Sprint(arg)
+		}
+		return spec.evaluate(stringArgs, s.context)
+	}
+}
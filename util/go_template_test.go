@@ -0,0 +1,132 @@
+package util
+
+import (
+	"github.com/vkrava4/curlson/app"
+	"github.com/vkrava4/curlson/faker"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func givenGoTemplateRenderer(t *testing.T, url string, headers []app.Header, body []byte) RequestRenderer {
+	var goTemplate, errCompile = CompileGoTemplate(url, headers, body)
+	if errCompile != nil {
+		t.Fatalf("Unexpected compile error: %v", errCompile)
+	}
+
+	return NewRequestRenderer(url, &app.RequestConfiguration{GoTemplate: goTemplate, Headers: headers, Body: body}).ForThread()
+}
+
+func TestGoTemplateRenderWithCSVRecord(t *testing.T) {
+	var givenHeaders = []app.Header{{Name: "X-Thread", Value: "{{.ThreadID}}-{{.Iteration}}"}}
+	var renderer = givenGoTemplateRenderer(t, "http://localhost:8080/users/{{index .Row \"0\"}}?seq={{seq}}", givenHeaders, nil)
+
+	var record, _ = ParseCSVRecord(`7,"Jane ""J"" Doe"`, DefaultTemplateDelimiter, map[string]int{"id": 0, "name": 1})
+	var rendered, errRender = renderer.Render(record, &FunctionContext{ThreadID: 2, Iteration: 3, Sequence: 11})
+	if errRender != nil || rendered.URL != "http://localhost:8080/users/7?seq=11" || rendered.Body != nil {
+		t.Errorf("Unexpected rendered request: %v, %v", rendered, errRender)
+	}
+
+	if len(rendered.Headers) != 1 || rendered.Headers[0].Name != "X-Thread" || rendered.Headers[0].Value != "2-3" {
+		t.Errorf("Unexpected rendered headers: %v", rendered.Headers)
+	}
+
+	if _, errCompile := CompileGoTemplate("http://localhost:8080", nil, []byte(`{{split .Row.name}}`)); errCompile == nil {
+		t.Error("A compile error is expected for an unknown function")
+	}
+}
+
+func TestGoTemplateRenderWithJSONRecordAndFunctions(t *testing.T) {
+	var givenBody = []byte(`{"user":{{toJson .Row.user}},"zip":"{{.Row.user.zip}}","code":"{{upper (trim " ab ")}}","uuid":"{{uuid}}"}`)
+	var renderer = givenGoTemplateRenderer(t, "http://localhost:8080/users/{{.Row.user.id}}", nil, givenBody)
+
+	var record, _ = ParseJSONRecord(`{"user":{"id":1,"zip":"01001"}}`)
+	var rendered, errRender = renderer.Render(record, &FunctionContext{Faker: faker.New(1)})
+	if errRender != nil || rendered.URL != "http://localhost:8080/users/1" {
+		t.Fatalf("Unexpected rendered request: %v, %v", rendered, errRender)
+	}
+
+	var expectedPrefix = `{"user":{"id":1,"zip":"01001"},"zip":"01001","code":"AB","uuid":"`
+	if !strings.HasPrefix(string(rendered.Body), expectedPrefix) || len(rendered.Body) != len(expectedPrefix)+38 {
+		t.Errorf("Unexpected rendered body: %s", rendered.Body)
+	}
+}
+
+func TestGoTemplateVarsAreKeptPerThread(t *testing.T) {
+	var givenBody = []byte(`{{$count := get "count"}}{{if $count}}{{set "count" (print $count "+")}}{{else}}{{set "count" "1"}}{{end}}{{get "count"}}`)
+	var goTemplate, _ = CompileGoTemplate("http://localhost:8080", nil, givenBody)
+	var renderer = NewRequestRenderer("http://localhost:8080", &app.RequestConfiguration{GoTemplate: goTemplate, Body: givenBody})
+
+	var firstThread = renderer.ForThread()
+	var secondThread = renderer.ForThread()
+
+	var expected = []string{"1", "1+", "1++"}
+	for i, expectedBody := range expected {
+		var rendered, errRender = firstThread.Render(nil, &FunctionContext{Iteration: i})
+		if errRender != nil || string(rendered.Body) != expectedBody {
+			t.Errorf("Unexpected rendered body of iteration %d: %v, %v", i, rendered, errRender)
+		}
+	}
+
+	var rendered, errRender = secondThread.Render(nil, &FunctionContext{})
+	if errRender != nil || string(rendered.Body) != "1" {
+		t.Errorf("Variables of threads should not be shared: %v, %v", rendered, errRender)
+	}
+}
+
+func TestGoTemplateRenderWithMissingKey(t *testing.T) {
+	var renderer = givenGoTemplateRenderer(t, "http://localhost:8080/users/{{.Row.id}}", nil, nil)
+
+	var record, _ = ParseJSONRecord(`{"name":"alice"}`)
+	var _, errRender = renderer.Render(record, &FunctionContext{})
+	if errRender == nil || !strings.Contains(errRender.Error(), `template: url:1:`) {
+		t.Errorf("An error with a template position is expected, but got %v", errRender)
+	}
+}
+
+func TestValidateGoTemplateEngine(t *testing.T) {
+	var givenFoundTemplate = "test.jsonl"
+	var testFileAbsPath, _ = filepath.Abs(givenFoundTemplate)
+	_ = ioutil.WriteFile(testFileAbsPath, []byte("{\"user\":{\"id\":1}}\n{\"name\":\"bob\"}\n"), filesMode)
+	defer os.Remove(testFileAbsPath)
+
+	var appConf = &app.Configuration{}
+	var getValidator = &GetValidator{}
+	var validatorEntity = getValidator.AddRequestCount(1).
+		AddThreads(1).
+		AddUrl("http://localhost:8080/users/{{.Row.user.id}}").
+		AddTemplate(givenFoundTemplate).
+		AddTemplateEngine(TemplateEngineGo).
+		WithAppConfiguration(appConf).
+		Entity()
+
+	var actualValidationResult = validatorEntity.Validate()
+
+	if actualValidationResult.valid || len(actualValidationResult.errMessages) != 1 ||
+		!strings.HasPrefix(actualValidationResult.errMessages[0], "Template line 2 is invalid. Reason: template: url:1:") {
+		t.Errorf("Unexpected validation result %v", actualValidationResult)
+	}
+
+	if appConf.Request.GoTemplate == nil {
+		t.Errorf("Unexpected app configuration result %v", appConf.Request)
+	}
+}
+
+func TestValidateGoTemplateEngineWithSyntaxError(t *testing.T) {
+	var getValidator = &GetValidator{}
+	var validatorEntity = getValidator.AddRequestCount(1).
+		AddThreads(1).
+		AddUrl("http://localhost:8080/users/{{.Row.id").
+		AddTemplateEngine(TemplateEngineGo).
+		WithAppConfiguration(&app.Configuration{}).
+		Entity()
+
+	var actualValidationResult = validatorEntity.Validate()
+
+	if actualValidationResult.valid || len(actualValidationResult.errMessages) != 1 ||
+		!strings.Contains(actualValidationResult.errMessages[0], "template: url:1:") {
+		t.Errorf("Unexpected validation result %v", actualValidationResult)
+	}
+}
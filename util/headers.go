@@ -0,0 +1,113 @@
+package util
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"github.com/vkrava4/curlson/app"
+	"os"
+	"strings"
+)
+
+// RedactedValue replaces sensitive values, such as header values or a request body, in shareable reports of a run
+const RedactedValue = "REDACTED"
+
+// ParseHeader parses a header given in a curl-like form of 'Name: value'
+func ParseHeader(header string) (app.Header, error) {
+	var separatorIndex = strings.Index(header, ":")
+	if separatorIndex < 1 {
+		return app.Header{}, errors.New("header should be in a form of 'Name: value'")
+	}
+
+	var name = strings.TrimSpace(header[:separatorIndex])
+	if !isHTTPToken(name) {
+		return app.Header{}, fmt.Errorf("header name '%s' contains illegal characters", name)
+	}
+
+	var value = strings.TrimSpace(header[separatorIndex+1:])
+	if strings.ContainsAny(value, "\r\n") {
+		return app.Header{}, errors.New("header value should not contain line breaks")
+	}
+
+	return app.Header{Name: name, Value: value}, nil
+}
+
+// RedactHeader replaces a value of a header given in a form of 'Name: value' with RedactedValue keeping its name
+func RedactHeader(header string) string {
+	var separatorIndex = strings.Index(header, ":")
+	if separatorIndex < 1 {
+		return RedactedValue
+	}
+
+	return strings.TrimSpace(header[:separatorIndex]) + ": " + RedactedValue
+}
+
+// ReadHeadersFile reads headers from a file which contains a single 'Name: value' header per line.
+// Empty lines and lines starting with '#' are ignored
+func ReadHeadersFile(path string) ([]string, error) {
+	var file, errOpen = os.Open(path)
+	if errOpen != nil {
+		return nil, errOpen
+	}
+	defer file.Close()
+
+	var headers []string
+	var scanner = bufio.NewScanner(file)
+	for scanner.Scan() {
+		var line = strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		headers = append(headers, line)
+	}
+
+	return headers, scanner.Err()
+}
+
+// PrepareHeaderValue resolves `#TE{key}` and `#T{key}` placeholders of a header value with values of a given record
+// the same way as PrepareUrl does for URL addresses
+func PrepareHeaderValue(valueTemplate string, record TemplateRecord) (string, error) {
+	var value = ReplacePlaceholders(valueTemplate, record)
+
+	if ContainsTemplatePlaceholders(value) {
+		return "", fmt.Errorf("Given header value: '%s' has unresolved placeholders", value)
+	}
+
+	if strings.ContainsAny(value, "\r\n") {
+		return "", fmt.Errorf("Given header value: '%s' contains line breaks", value)
+	}
+
+	return value, nil
+}
+
+func validateHeaders(headers []string, headersFile string, result *ValidationResult) []app.Header {
+	var allHeaders []string
+	if headersFile != "" {
+		var fileHeaders, errReadHeaders = ReadHeadersFile(headersFile)
+		if errReadHeaders != nil {
+			result.valid = false
+			result.errMessages = append(result.errMessages, fmt.Sprintf(MsgCantReadHeadersWithReason, headersFile, errReadHeaders.Error()))
+		}
+		allHeaders = append(allHeaders, fileHeaders...)
+	}
+	allHeaders = append(allHeaders, headers...)
+
+	var parsedHeaders []app.Header
+	for _, header := range allHeaders {
+		var parsedHeader, errParse = ParseHeader(header)
+		if errParse != nil {
+			result.valid = false
+			result.errMessages = append(result.errMessages, fmt.Sprintf(MsgHeaderInvalidWithReason, header, errParse.Error()))
+			continue
+		}
+
+		parsedHeaders = append(parsedHeaders, parsedHeader)
+	}
+
+	if result.conf != nil {
+		result.conf.Request.Headers = parsedHeaders
+	}
+
+	return parsedHeaders
+}
@@ -0,0 +1,143 @@
+package util
+
+import (
+	"github.com/vkrava4/curlson/app"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseHeader(t *testing.T) {
+	var expectations = map[string]app.Header{
+		"Accept: application/json":   {Name: "Accept", Value: "application/json"},
+		"X-Empty:":                   {Name: "X-Empty", Value: ""},
+		" Authorization :Bearer a:b": {Name: "Authorization", Value: "Bearer a:b"},
+	}
+
+	for given, expected := range expectations {
+		var actual, errParse = ParseHeader(given)
+		if errParse != nil || actual != expected {
+			t.Errorf("Unexpected header for '%s', actual: %v (%v), expected: %v", given, actual, errParse, expected)
+		}
+	}
+}
+
+func TestParseInvalidHeader(t *testing.T) {
+	for _, given := range []string{"", "Accept", ": value", "Bad Name: value", "Name: line\nbreak"} {
+		if _, errParse := ParseHeader(given); errParse == nil {
+			t.Errorf("An error is expected for header '%s'", given)
+		}
+	}
+}
+
+func TestRedactHeader(t *testing.T) {
+	var expectations = map[string]string{
+		"Authorization: Bearer secret": "Authorization: REDACTED",
+		"X-Api-Key:secret":             "X-Api-Key: REDACTED",
+		"malformed":                    "REDACTED",
+	}
+
+	for given, expected := range expectations {
+		if actual := RedactHeader(given); actual != expected {
+			t.Errorf("Unexpected redacted header of '%s', actual: '%s', expected: '%s'", given, actual, expected)
+		}
+	}
+}
+
+func TestPrepareHeaderValue(t *testing.T) {
+	var actual, errPrepare = PrepareHeaderValue("Bearer #T{1} for #TE{0}", givenTemplateRecord("john doe,token"))
+
+	if errPrepare != nil || actual != "Bearer token for john+doe" {
+		t.Errorf("Unexpected header value: '%s', %v", actual, errPrepare)
+	}
+
+	if _, errPrepare = PrepareHeaderValue("Bearer #T{2}", givenTemplateRecord("john,token")); errPrepare == nil {
+		t.Error("An error is expected for unresolved placeholders")
+	}
+}
+
+func TestReadHeadersFile(t *testing.T) {
+	var testFileAbsPath, _ = filepath.Abs("test_headers.file")
+	_ = ioutil.WriteFile(testFileAbsPath, []byte("# comment\nAccept: */*\n\n  X-Tenant: #T{0}  \n"), filesMode)
+	defer os.Remove(testFileAbsPath)
+
+	var headers, errRead = ReadHeadersFile(testFileAbsPath)
+
+	if errRead != nil || strings.Join(headers, "|") != "Accept: */*|X-Tenant: #T{0}" {
+		t.Errorf("Unexpected headers: %v, %v", headers, errRead)
+	}
+}
+
+func TestValidateHeadersWithoutTemplate(t *testing.T) {
+	var appConf = &app.Configuration{}
+	var getValidator = &GetValidator{}
+	var validatorEntity = getValidator.AddRequestCount(1).
+		AddThreads(1).
+		AddUrl("http://localhost:8080").
+		AddHeaders([]string{"Accept: application/json", "Invalid"}, "").
+		WithAppConfiguration(appConf).
+		Entity()
+
+	var actualValidationResult = validatorEntity.Validate()
+
+	if actualValidationResult.valid || len(actualValidationResult.errMessages) != 1 ||
+		!strings.HasPrefix(actualValidationResult.errMessages[0], "Provided header 'Invalid' is invalid") {
+		t.Errorf("Unexpected validation result %v", actualValidationResult)
+	}
+
+	if len(appConf.Request.Headers) != 1 || appConf.Request.Headers[0].Name != "Accept" {
+		t.Errorf("Unexpected app request configuration %v", appConf.Request)
+	}
+}
+
+func TestValidateHeadersWithPlaceholdersForExistingTemplate(t *testing.T) {
+	var givenFoundTemplate = "test.file"
+	var testFileAbsPath, _ = filepath.Abs(givenFoundTemplate)
+	_ = ioutil.WriteFile(testFileAbsPath, []byte("alice,token1\nbob,token2\n"), filesMode)
+	defer os.Remove(testFileAbsPath)
+
+	var appConf = &app.Configuration{}
+	var getValidator = &GetValidator{}
+	var validatorEntity = getValidator.AddRequestCount(1).
+		AddThreads(1).
+		AddUrl("http://localhost:8080/users").
+		AddTemplate(givenFoundTemplate).
+		AddHeaders([]string{"Authorization: Bearer #T{1}"}, "").
+		WithAppConfiguration(appConf).
+		Entity()
+
+	var actualValidationResult = validatorEntity.Validate()
+
+	if !actualValidationResult.valid || len(actualValidationResult.errMessages) > 0 || len(actualValidationResult.warnMessages) > 0 {
+		t.Errorf("Unexpected validation result %v", actualValidationResult)
+	}
+
+	if !appConf.Template.Enabled || templateSource(appConf.Template, "").Size != 2 {
+		t.Errorf("Unexpected app configuration result %v", appConf.Template)
+	}
+}
+
+func TestValidateHeadersWithUnresolvedPlaceholdersForExistingTemplate(t *testing.T) {
+	var givenFoundTemplate = "test.file"
+	var testFileAbsPath, _ = filepath.Abs(givenFoundTemplate)
+	_ = ioutil.WriteFile(testFileAbsPath, []byte("alice,token1\nbob\n"), filesMode)
+	defer os.Remove(testFileAbsPath)
+
+	var getValidator = &GetValidator{}
+	var validatorEntity = getValidator.AddRequestCount(1).
+		AddThreads(1).
+		AddUrl("http://localhost:8080/users/#T{0}").
+		AddTemplate(givenFoundTemplate).
+		AddHeaders([]string{"Authorization: Bearer #T{1}"}, "").
+		WithAppConfiguration(&app.Configuration{}).
+		Entity()
+
+	var actualValidationResult = validatorEntity.Validate()
+
+	if actualValidationResult.valid || strings.Join(actualValidationResult.errMessages, ",") !=
+		"Template line 2 is invalid. Reason: there is no value for placeholder key '1'" {
+		t.Errorf("Unexpected validation result %v", actualValidationResult)
+	}
+}
@@ -0,0 +1,89 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// JSONRecord holds a single JSON Lines template line. Its values are referenced by dotted paths
+// of fields, e.g. `user.address.zip`, where numeric segments are indexes of arrays, e.g. `orders.0.id`
+type JSONRecord struct {
+	line string
+	data interface{}
+}
+
+// ParseJSONRecord parses a given template line as a JSON value
+func ParseJSONRecord(line string) (*JSONRecord, error) {
+	var decoder = json.NewDecoder(strings.NewReader(line))
+	decoder.UseNumber()
+
+	var data interface{}
+	if errDecode := decoder.Decode(&data); errDecode != nil {
+		return nil, fmt.Errorf("Template line '%s' is not a valid JSON. Reason: %s", abbreviate(line, 120), errDecode.Error())
+	}
+
+	if decoder.More() {
+		return nil, fmt.Errorf("Template line '%s' should hold a single JSON value", abbreviate(line, 120))
+	}
+
+	return &JSONRecord{line: line, data: data}, nil
+}
+
+// Value returns a value of a field by its dotted path. Strings are returned as is, 'null' as an empty string,
+// objects and arrays as compact JSON. The returned boolean is false when there is no such field
+func (r *JSONRecord) Value(path string) (string, bool) {
+	var current = r.data
+	for _, segment := range strings.Split(path, ".") {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			var field, exists = node[segment]
+			if !exists {
+				return "", false
+			}
+			current = field
+
+		case []interface{}:
+			var index, errIndex = strconv.Atoi(segment)
+			if errIndex != nil || index < 0 || index >= len(node) {
+				return "", false
+			}
+			current = node[index]
+
+		default:
+			return "", false
+		}
+	}
+
+	switch value := current.(type) {
+	case nil:
+		return "", true
+
+	case string:
+		return value, true
+
+	case json.Number:
+		return value.String(), true
+
+	case bool:
+		return strconv.FormatBool(value), true
+
+	default:
+		var encoded, errEncode = json.Marshal(value)
+		if errEncode != nil {
+			return "", false
+		}
+		return string(encoded), true
+	}
+}
+
+// Data returns the decoded JSON value
+func (r *JSONRecord) Data() interface{} {
+	return r.data
+}
+
+// String returns the original template line
+func (r *JSONRecord) String() string {
+	return r.line
+}
@@ -0,0 +1,40 @@
+package util
+
+import "testing"
+
+func TestJSONRecordValueByDottedPath(t *testing.T) {
+	var record, errParse = ParseJSONRecord(`{"id":42,"user":{"name":"Jane","address":{"zip":"01001"}},"orders":[{"id":"a1"},{"id":"a2"}],"vip":true,"note":null,"tags":["x","y"]}`)
+	if errParse != nil {
+		t.Fatalf("Unexpected error: %v", errParse)
+	}
+
+	var expectations = map[string]string{
+		"id":               "42",
+		"user.name":        "Jane",
+		"user.address.zip": "01001",
+		"orders.1.id":      "a2",
+		"vip":              "true",
+		"note":             "",
+		"tags":             `["x","y"]`,
+	}
+
+	for path, expected := range expectations {
+		if actual, exists := record.Value(path); !exists || actual != expected {
+			t.Errorf("Unexpected value for path '%s', actual: '%s', expected: '%s'", path, actual, expected)
+		}
+	}
+
+	for _, path := range []string{"missing", "user.phone", "orders.2.id", "orders.x", "id.value"} {
+		if _, exists := record.Value(path); exists {
+			t.Errorf("Value for path '%s' should not exist", path)
+		}
+	}
+}
+
+func TestParseInvalidJSONRecord(t *testing.T) {
+	for _, given := range []string{"", "{\"id\":", "{} {}"} {
+		if _, errParse := ParseJSONRecord(given); errParse == nil {
+			t.Errorf("An error is expected for line '%s'", given)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package util
+
+import (
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// TemplateModeRandom picks a random template line for every request
+	TemplateModeRandom = "random"
+
+	// TemplateModeSequential walks through template lines in order with a cursor shared by all threads,
+	// starting over once the last line is reached
+	TemplateModeSequential = "sequential"
+
+	// TemplateModePerThread partitions template lines across threads so that every thread walks through its own slice in order
+	TemplateModePerThread = "per-thread"
+
+	// TemplateModeOnce walks through template lines in order with a shared cursor and stops once every line was used
+	TemplateModeOnce = "once"
+)
+
+var templateModes = []string{TemplateModeRandom, TemplateModeSequential, TemplateModePerThread, TemplateModeOnce}
+
+// LineSelector chooses a template line number for every request according to a template mode.
+// It's safe for a concurrent use by multiple goroutines as long as every goroutine uses its own thread id
+type LineSelector struct {
+	// cursor is a shared position of 'sequential' and 'once' modes. It's kept first to be 64-bit aligned
+	cursor int64
+
+	mode    string
+	size    int
+	threads int
+
+	random      *rand.Rand
+	randomMutex sync.Mutex
+
+	// threadCursors are positions of threads inside of their partitions in 'per-thread' mode
+	threadCursors []int
+}
+
+// NewLineSelector creates a LineSelector for a given template mode, number of template lines and threads.
+// A given seed makes 'random' mode reproducible, zero seed means a random one
+func NewLineSelector(mode string, size int, threads int, seed int64) *LineSelector {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	return &LineSelector{
+		mode:          mode,
+		size:          size,
+		threads:       threads,
+		random:        rand.New(rand.NewSource(seed)),
+		threadCursors: make([]int, threads),
+	}
+}
+
+// Next returns a number of the next template line for a thread with a given id.
+// The returned boolean is false when lines are exhausted in 'once' mode or there are no lines for the thread
+func (s *LineSelector) Next(threadID int) (int, bool) {
+	if s.size < 1 {
+		return -1, false
+	}
+
+	switch s.mode {
+	case TemplateModeSequential:
+		return int((atomic.AddInt64(&s.cursor, 1) - 1) % int64(s.size)), true
+
+	case TemplateModeOnce:
+		var lineNumber = atomic.AddInt64(&s.cursor, 1) - 1
+		if lineNumber >= int64(s.size) {
+			return -1, false
+		}
+		return int(lineNumber), true
+
+	case TemplateModePerThread:
+		var from, to = s.partition(threadID)
+		if from == to {
+			return -1, false
+		}
+
+		var lineNumber = from + s.threadCursors[threadID]%(to-from)
+		s.threadCursors[threadID]++
+		return lineNumber, true
+
+	default:
+		s.randomMutex.Lock()
+		defer s.randomMutex.Unlock()
+		return s.random.Intn(s.size), true
+	}
+}
+
+// partition returns a range of template lines [from, to) which belongs to a thread with a given id in 'per-thread' mode
+func (s *LineSelector) partition(threadID int) (int, int) {
+	return threadID * s.size / s.threads, (threadID + 1) * s.size / s.threads
+}
@@ -0,0 +1,90 @@
+package util
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestLineSelectorSequential(t *testing.T) {
+	var selector = NewLineSelector(TemplateModeSequential, 3, 2, 0)
+
+	var actual []int
+	for i := 0; i < 5; i++ {
+		var lineNumber, ok = selector.Next(i % 2)
+		if !ok {
+			t.Fatal("Sequential mode should never be exhausted")
+		}
+		actual = append(actual, lineNumber)
+	}
+
+	if expected := []int{0, 1, 2, 0, 1}; !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Unexpected sequential lines, actual: %v, expected: %v", actual, expected)
+	}
+}
+
+func TestLineSelectorPerThread(t *testing.T) {
+	var selector = NewLineSelector(TemplateModePerThread, 5, 2, 0)
+
+	var expectations = map[int][]int{
+		0: {0, 1, 0},
+		1: {2, 3, 4, 2},
+	}
+
+	for threadID, expected := range expectations {
+		var actual []int
+		for range expected {
+			var lineNumber, _ = selector.Next(threadID)
+			actual = append(actual, lineNumber)
+		}
+
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("Unexpected lines for thread %d, actual: %v, expected: %v", threadID, actual, expected)
+		}
+	}
+}
+
+func TestLineSelectorOnce(t *testing.T) {
+	var selector = NewLineSelector(TemplateModeOnce, 100, 4, 0)
+	var used = make([]int, 100)
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+
+	for i := 0; i < 4; i++ {
+		var threadID = i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				var lineNumber, ok = selector.Next(threadID)
+				if !ok {
+					return
+				}
+
+				mutex.Lock()
+				used[lineNumber]++
+				mutex.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	for lineNumber, times := range used {
+		if times != 1 {
+			t.Errorf("Line %d was used %d time(s) instead of once", lineNumber, times)
+		}
+	}
+}
+
+func TestLineSelectorRandomWithSeed(t *testing.T) {
+	var first = NewLineSelector(TemplateModeRandom, 1000, 1, 42)
+	var second = NewLineSelector(TemplateModeRandom, 1000, 1, 42)
+
+	for i := 0; i < 10; i++ {
+		var firstLine, _ = first.Next(0)
+		var secondLine, _ = second.Next(0)
+		if firstLine != secondLine {
+			t.Fatalf("Random lines with the same seed should be equal, actual: %d and %d", firstLine, secondLine)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package util
+
+import (
+	"fmt"
+	"github.com/vkrava4/curlson/load"
+	"net"
+	"time"
+)
+
+// validateRate parses a given rate of a constant arrival rate mode and stores it into app configuration
+func validateRate(rate string, sleep int, result *ValidationResult) {
+	if rate == "" {
+		return
+	}
+
+	var parsedRate, errParse = load.ParseRate(rate)
+	if errParse != nil {
+		result.valid = false
+		result.errMessages = append(result.errMessages, fmt.Sprintf(MsgRateInvalidWithReason, rate, errParse.Error()))
+		return
+	}
+
+	if sleep > 0 {
+		result.warnMessages = append(result.warnMessages, MsgSleepIgnoredWithRate)
+	}
+
+	if result.conf != nil {
+		result.conf.Load.Rate = parsedRate
+	}
+}
+
+// validateStages parses given stages of a load profile and stores them into app configuration.
+// It returns the profile or nil when there are no stages or they are invalid
+func validateStages(stages []string, stageType string, rate string, sleep int, requestCount int, result *ValidationResult) *load.Profile {
+	if len(stages) == 0 {
+		return nil
+	}
+
+	var valid = true
+	var parsedStages = make([]load.Stage, 0, len(stages))
+	for _, value := range stages {
+		var stage, errParse = load.ParseStage(value)
+		if errParse != nil {
+			valid = false
+			result.errMessages = append(result.errMessages, fmt.Sprintf(MsgStageInvalidWithReason, value, errParse.Error()))
+			continue
+		}
+		parsedStages = append(parsedStages, stage)
+	}
+
+	var profile = load.NewProfile(parsedStages)
+	if valid && profile.MaxTarget() == 0 {
+		valid = false
+		result.errMessages = append(result.errMessages, MsgStagesTargetsZero)
+	}
+
+	if rate != "" {
+		valid = false
+		result.errMessages = append(result.errMessages, MsgStagesWithRate)
+	}
+
+	validateOneOf("Stage type", stageType, load.StageTypes, result)
+	if !valid {
+		result.valid = false
+		return nil
+	}
+
+	if requestCount > 1 {
+		result.warnMessages = append(result.warnMessages, MsgCountIgnoredWithStages)
+	}
+	if sleep > 0 && stageType == load.StageTypeRate {
+		result.warnMessages = append(result.warnMessages, MsgSleepIgnoredWithRate)
+	}
+
+	if result.conf != nil {
+		result.conf.Load.Stages = parsedStages
+		result.conf.Load.StageType = stageType
+	}
+	return profile
+}
+
+// validateDuration parses a given duration of a run without a request count and stores it into app configuration
+func validateDuration(duration string, requestCount int, withStages bool, result *ValidationResult) {
+	if duration == "" {
+		return
+	}
+
+	var parsedDuration, errParse = time.ParseDuration(duration)
+	if errParse != nil || parsedDuration <= 0 {
+		result.valid = false
+		result.errMessages = append(result.errMessages, fmt.Sprintf(MsgDurationInvalidWithReason, duration, "it should be a positive duration like '30s' or '5m'"))
+		return
+	}
+
+	if withStages {
+		result.valid = false
+		result.errMessages = append(result.errMessages, MsgDurationWithStages)
+		return
+	}
+
+	if requestCount > 1 {
+		result.warnMessages = append(result.warnMessages, MsgCountIgnoredWithDuration)
+	}
+
+	if result.conf != nil {
+		result.conf.Load.Duration = parsedDuration
+	}
+}
+
+// validateThinkTime parses a given think time or converts a given delay in millis to a fixed think time and stores it
+// into app configuration. Think time is ignored when requests are sent on a fixed timetable of a rate
+func validateThinkTime(thinkTime string, sleep int, withRate bool, withTemplate bool, result *ValidationResult) {
+	if thinkTime == "" {
+		if sleep > 0 && !withRate && result.conf != nil {
+			result.conf.Load.ThinkTime = &load.ThinkTime{Distribution: load.ThinkTimeFixed, Min: time.Duration(sleep) * time.Millisecond}
+		}
+		return
+	}
+
+	var parsedThinkTime, errParse = load.ParseThinkTime(thinkTime)
+	if errParse != nil {
+		result.valid = false
+		result.errMessages = append(result.errMessages, fmt.Sprintf(MsgThinkTimeInvalidWithReason, thinkTime, errParse.Error()))
+		return
+	}
+
+	if sleep > 0 {
+		result.valid = false
+		result.errMessages = append(result.errMessages, MsgThinkTimeWithSleep)
+		return
+	}
+
+	if parsedThinkTime.Distribution == load.ThinkTimeTemplate && !withTemplate {
+		result.valid = false
+		result.errMessages = append(result.errMessages, fmt.Sprintf(MsgThinkTimeTemplateRequired, thinkTime))
+		return
+	}
+
+	if withRate {
+		result.warnMessages = append(result.warnMessages, MsgThinkTimeIgnoredWithRate)
+		return
+	}
+
+	if result.conf != nil {
+		result.conf.Load.ThinkTime = parsedThinkTime
+	}
+}
+
+// validateMaxRate parses a given max rate of all threads together and its burst, validates an address of a control endpoint
+// which changes the max rate during a run and stores them into app configuration
+func validateMaxRate(maxRate string, burst int, controlAddress string, withMaxRateStages bool, result *ValidationResult) {
+	if maxRate == "" && !withMaxRateStages {
+		if controlAddress != "" {
+			result.valid = false
+			result.errMessages = append(result.errMessages, MsgControlAddressWithoutRate)
+		}
+		return
+	}
+
+	validatePositive("Max rate burst", burst, result)
+
+	var parsedMaxRate float64
+	if maxRate != "" {
+		var errParse error
+		parsedMaxRate, errParse = load.ParseRate(maxRate)
+		if errParse != nil {
+			result.valid = false
+			result.errMessages = append(result.errMessages, fmt.Sprintf(MsgMaxRateInvalidWithReason, maxRate, errParse.Error()))
+			return
+		}
+
+		if withMaxRateStages {
+			result.valid = false
+			result.errMessages = append(result.errMessages, MsgMaxRateWithStages)
+			return
+		}
+	}
+
+	if controlAddress != "" {
+		if _, _, errSplit := net.SplitHostPort(controlAddress); errSplit != nil {
+			result.valid = false
+			result.errMessages = append(result.errMessages, fmt.Sprintf(MsgControlAddressInvalid, controlAddress, errSplit.Error()))
+			return
+		}
+
+		if withMaxRateStages {
+			result.valid = false
+			result.errMessages = append(result.errMessages, MsgControlAddressWithStages)
+			return
+		}
+	}
+
+	if result.conf != nil {
+		result.conf.Load.MaxRate = parsedMaxRate
+		result.conf.Load.MaxRateBurst = burst
+		result.conf.Load.ControlAddress = controlAddress
+	}
+}
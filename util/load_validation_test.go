@@ -0,0 +1,352 @@
+package util
+
+import (
+	"fmt"
+	"github.com/vkrava4/curlson/app"
+	"github.com/vkrava4/curlson/load"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateRate(t *testing.T) {
+	var appConf = &app.Configuration{}
+	var getValidator = &GetValidator{}
+	var validatorEntity = getValidator.AddRequestCount(100).
+		AddThreads(4).
+		AddSleep(10).
+		AddUrl("http://localhost:8080/users").
+		AddRate("30/m").
+		WithAppConfiguration(appConf).
+		Entity()
+
+	var actualValidationResult = validatorEntity.Validate()
+
+	if !actualValidationResult.valid || strings.Join(actualValidationResult.warnMessages, ",") != MsgSleepIgnoredWithRate {
+		t.Errorf("Unexpected validation result %v", actualValidationResult)
+	}
+
+	if appConf.Load.Rate != 0.5 {
+		t.Errorf("Unexpected app configuration result %v", appConf.Load)
+	}
+}
+
+func TestValidateInvalidRate(t *testing.T) {
+	var getValidator = &GetValidator{}
+	var validatorEntity = getValidator.AddRequestCount(100).
+		AddThreads(4).
+		AddUrl("http://localhost:8080/users").
+		AddRate("0/s").
+		WithAppConfiguration(&app.Configuration{}).
+		Entity()
+
+	var actualValidationResult = validatorEntity.Validate()
+
+	var expectedErrMessage = "Provided rate '0/s' is invalid. Reason: a number of requests '0' should be a positive number"
+	if actualValidationResult.valid || strings.Join(actualValidationResult.errMessages, ",") != expectedErrMessage {
+		t.Errorf("Unexpected validation result %v", actualValidationResult)
+	}
+}
+
+func TestValidateStages(t *testing.T) {
+	var appConf = &app.Configuration{}
+	var getValidator = &GetValidator{}
+	var validatorEntity = getValidator.AddRequestCount(1).
+		AddThreads(1).
+		AddUrl("http://localhost:8080/users").
+		AddStages([]string{"30s:10", "2m:200", "30s:0"}, load.StageTypeThreads).
+		WithAppConfiguration(appConf).
+		Entity()
+
+	var actualValidationResult = validatorEntity.Validate()
+
+	if !actualValidationResult.valid || len(actualValidationResult.warnMessages) != 0 {
+		t.Errorf("Unexpected validation result %v", actualValidationResult)
+	}
+
+	if len(appConf.Load.Stages) != 3 || appConf.Load.Stages[1] != (load.Stage{Duration: 2 * time.Minute, Target: 200}) ||
+		appConf.Load.StageType != load.StageTypeThreads {
+		t.Errorf("Unexpected app configuration result %v", appConf.Load)
+	}
+}
+
+func TestValidateStagesWithCount(t *testing.T) {
+	var getValidator = &GetValidator{}
+	var validatorEntity = getValidator.AddRequestCount(100).
+		AddThreads(1).
+		AddSleep(10).
+		AddUrl("http://localhost:8080/users").
+		AddStages([]string{"1m:10"}, load.StageTypeRate).
+		WithAppConfiguration(&app.Configuration{}).
+		Entity()
+
+	var actualValidationResult = validatorEntity.Validate()
+
+	var expectedWarnMessages = MsgCountIgnoredWithStages + "," + MsgSleepIgnoredWithRate
+	if !actualValidationResult.valid || strings.Join(actualValidationResult.warnMessages, ",") != expectedWarnMessages {
+		t.Errorf("Unexpected validation result %v", actualValidationResult)
+	}
+}
+
+func TestValidateInvalidStages(t *testing.T) {
+	var appConf = &app.Configuration{}
+	var getValidator = &GetValidator{}
+	var validatorEntity = getValidator.AddRequestCount(1).
+		AddThreads(1).
+		AddUrl("http://localhost:8080/users").
+		AddRate("10/s").
+		AddStages([]string{"30s", "10s:0"}, "users").
+		WithAppConfiguration(appConf).
+		Entity()
+
+	var actualValidationResult = validatorEntity.Validate()
+
+	var expectedErrMessages = []string{
+		"Provided stage '30s' is invalid. Reason: expected format is 'duration:target', e.g. '30s:10'",
+		MsgStagesWithRate,
+		"Stage type should be one of: threads, rate, max-rate. Currently it's: 'users'",
+	}
+	if actualValidationResult.valid || strings.Join(actualValidationResult.errMessages, ",") != strings.Join(expectedErrMessages, ",") {
+		t.Errorf("Unexpected validation result %v", actualValidationResult)
+	}
+
+	if len(appConf.Load.Stages) != 0 {
+		t.Errorf("Unexpected app configuration result %v", appConf.Load)
+	}
+}
+
+func TestValidateStagesWithZeroTargets(t *testing.T) {
+	var getValidator = &GetValidator{}
+	var validatorEntity = getValidator.AddRequestCount(1).
+		AddThreads(1).
+		AddUrl("http://localhost:8080/users").
+		AddStages([]string{"10s:0", "10s:0"}, load.StageTypeThreads).
+		WithAppConfiguration(&app.Configuration{}).
+		Entity()
+
+	var actualValidationResult = validatorEntity.Validate()
+
+	if actualValidationResult.valid || strings.Join(actualValidationResult.errMessages, ",") != MsgStagesTargetsZero {
+		t.Errorf("Unexpected validation result %v", actualValidationResult)
+	}
+}
+
+func TestValidateDuration(t *testing.T) {
+	var appConf = &app.Configuration{}
+	var getValidator = &GetValidator{}
+	var validatorEntity = getValidator.AddRequestCount(100).
+		AddThreads(4).
+		AddUrl("http://localhost:8080/users").
+		AddDuration("5m").
+		WithAppConfiguration(appConf).
+		Entity()
+
+	var actualValidationResult = validatorEntity.Validate()
+
+	if !actualValidationResult.valid || strings.Join(actualValidationResult.warnMessages, ",") != MsgCountIgnoredWithDuration {
+		t.Errorf("Unexpected validation result %v", actualValidationResult)
+	}
+
+	if appConf.Load.Duration != 5*time.Minute {
+		t.Errorf("Unexpected app configuration result %v", appConf.Load)
+	}
+}
+
+func TestValidateInvalidDuration(t *testing.T) {
+	for _, givenDuration := range []string{"5", "-5s", "0s", "five minutes"} {
+		var getValidator = &GetValidator{}
+		var validatorEntity = getValidator.AddRequestCount(1).
+			AddThreads(1).
+			AddUrl("http://localhost:8080/users").
+			AddDuration(givenDuration).
+			WithAppConfiguration(&app.Configuration{}).
+			Entity()
+
+		var actualValidationResult = validatorEntity.Validate()
+
+		var expectedErrMessage = fmt.Sprintf(MsgDurationInvalidWithReason, givenDuration, "it should be a positive duration like '30s' or '5m'")
+		if actualValidationResult.valid || strings.Join(actualValidationResult.errMessages, ",") != expectedErrMessage {
+			t.Errorf("Unexpected validation result %v", actualValidationResult)
+		}
+	}
+}
+
+func TestValidateDurationWithStages(t *testing.T) {
+	var getValidator = &GetValidator{}
+	var validatorEntity = getValidator.AddRequestCount(1).
+		AddThreads(1).
+		AddUrl("http://localhost:8080/users").
+		AddStages([]string{"1m:10"}, load.StageTypeThreads).
+		AddDuration("5m").
+		WithAppConfiguration(&app.Configuration{}).
+		Entity()
+
+	var actualValidationResult = validatorEntity.Validate()
+
+	if actualValidationResult.valid || strings.Join(actualValidationResult.errMessages, ",") != MsgDurationWithStages {
+		t.Errorf("Unexpected validation result %v", actualValidationResult)
+	}
+}
+
+func TestValidateThinkTime(t *testing.T) {
+	var appConf = &app.Configuration{}
+	var getValidator = &GetValidator{}
+	var validatorEntity = getValidator.AddRequestCount(10).
+		AddThreads(2).
+		AddUrl("http://localhost:8080/users").
+		AddThinkTime("100ms..500ms").
+		WithAppConfiguration(appConf).
+		Entity()
+
+	var actualValidationResult = validatorEntity.Validate()
+
+	if !actualValidationResult.valid {
+		t.Errorf("Unexpected validation result %v", actualValidationResult)
+	}
+
+	var expectedThinkTime = load.ThinkTime{Distribution: load.ThinkTimeUniform, Min: 100 * time.Millisecond, Max: 500 * time.Millisecond}
+	if appConf.Load.ThinkTime == nil || *appConf.Load.ThinkTime != expectedThinkTime {
+		t.Errorf("Unexpected app configuration result %v", appConf.Load.ThinkTime)
+	}
+}
+
+func TestValidateSleepAsFixedThinkTime(t *testing.T) {
+	var appConf = &app.Configuration{}
+	var getValidator = &GetValidator{}
+	var validatorEntity = getValidator.AddRequestCount(10).
+		AddThreads(2).
+		AddSleep(250).
+		AddUrl("http://localhost:8080/users").
+		WithAppConfiguration(appConf).
+		Entity()
+
+	var actualValidationResult = validatorEntity.Validate()
+
+	var expectedThinkTime = load.ThinkTime{Distribution: load.ThinkTimeFixed, Min: 250 * time.Millisecond}
+	if !actualValidationResult.valid || appConf.Load.ThinkTime == nil || *appConf.Load.ThinkTime != expectedThinkTime {
+		t.Errorf("Unexpected validation result %v or think time %v", actualValidationResult, appConf.Load.ThinkTime)
+	}
+}
+
+func TestValidateInvalidThinkTime(t *testing.T) {
+	var givenExpectations = []struct {
+		thinkTime  string
+		sleep      int
+		errMessage string
+	}{
+		{"gamma:1s", 0, "Provided think time 'gamma:1s' is invalid. Reason: unknown distribution 'gamma', expected one of: normal, exponential, template"},
+		{"1s", 100, MsgThinkTimeWithSleep},
+		{"template:think_ms", 0, "Think time 'template:think_ms' takes values from a template, but template file is not set"},
+	}
+
+	for _, given := range givenExpectations {
+		var getValidator = &GetValidator{}
+		var validatorEntity = getValidator.AddRequestCount(1).
+			AddThreads(1).
+			AddSleep(given.sleep).
+			AddUrl("http://localhost:8080/users").
+			AddThinkTime(given.thinkTime).
+			WithAppConfiguration(&app.Configuration{}).
+			Entity()
+
+		var actualValidationResult = validatorEntity.Validate()
+
+		if actualValidationResult.valid || strings.Join(actualValidationResult.errMessages, ",") != given.errMessage {
+			t.Errorf("Unexpected validation result %v of think time '%s'", actualValidationResult, given.thinkTime)
+		}
+	}
+}
+
+func TestValidateThinkTimeWithRate(t *testing.T) {
+	var appConf = &app.Configuration{}
+	var getValidator = &GetValidator{}
+	var validatorEntity = getValidator.AddRequestCount(10).
+		AddThreads(2).
+		AddUrl("http://localhost:8080/users").
+		AddRate("10/s").
+		AddThinkTime("exponential:1s").
+		WithAppConfiguration(appConf).
+		Entity()
+
+	var actualValidationResult = validatorEntity.Validate()
+
+	if !actualValidationResult.valid || strings.Join(actualValidationResult.warnMessages, ",") != MsgThinkTimeIgnoredWithRate || appConf.Load.ThinkTime != nil {
+		t.Errorf("Unexpected validation result %v", actualValidationResult)
+	}
+}
+
+func TestValidateMaxRate(t *testing.T) {
+	var appConf = &app.Configuration{}
+	var getValidator = &GetValidator{}
+	var validatorEntity = getValidator.AddRequestCount(10).
+		AddThreads(20).
+		AddUrl("http://localhost:8080/users").
+		AddMaxRate("200/s", 10, "127.0.0.1:6060").
+		WithAppConfiguration(appConf).
+		Entity()
+
+	var actualValidationResult = validatorEntity.Validate()
+
+	if !actualValidationResult.valid || len(actualValidationResult.warnMessages) != 0 {
+		t.Errorf("Unexpected validation result %v", actualValidationResult)
+	}
+
+	if appConf.Load.MaxRate != 200 || appConf.Load.MaxRateBurst != 10 || appConf.Load.ControlAddress != "127.0.0.1:6060" {
+		t.Errorf("Unexpected app configuration result %v", appConf.Load)
+	}
+}
+
+func TestValidateMaxRateStages(t *testing.T) {
+	var appConf = &app.Configuration{}
+	var getValidator = &GetValidator{}
+	var validatorEntity = getValidator.AddRequestCount(1).
+		AddThreads(20).
+		AddUrl("http://localhost:8080/users").
+		AddStages([]string{"30s:100", "1m:500"}, load.StageTypeMaxRate).
+		AddMaxRate("", 1, "").
+		WithAppConfiguration(appConf).
+		Entity()
+
+	var actualValidationResult = validatorEntity.Validate()
+
+	if !actualValidationResult.valid || len(actualValidationResult.warnMessages) != 0 {
+		t.Errorf("Unexpected validation result %v", actualValidationResult)
+	}
+
+	if len(appConf.Load.Stages) != 2 || appConf.Load.StageType != load.StageTypeMaxRate || appConf.Load.MaxRateBurst != 1 {
+		t.Errorf("Unexpected app configuration result %v", appConf.Load)
+	}
+}
+
+func TestValidateInvalidMaxRate(t *testing.T) {
+	var givenValidators = map[string]GetValidatorBuilder{
+		fmt.Sprintf(MsgMaxRateInvalidWithReason, "fast", "a number of requests 'fast' should be a positive number"): (&GetValidator{}).
+			AddMaxRate("fast", 1, ""),
+		"Max rate burst should be positive. Currently it's: '0'": (&GetValidator{}).
+			AddMaxRate("10/s", 0, ""),
+		MsgMaxRateWithStages: (&GetValidator{}).
+			AddStages([]string{"1m:10"}, load.StageTypeMaxRate).
+			AddMaxRate("10/s", 1, ""),
+		MsgControlAddressWithStages: (&GetValidator{}).
+			AddStages([]string{"1m:10"}, load.StageTypeMaxRate).
+			AddMaxRate("", 1, ":6060"),
+		MsgControlAddressWithoutRate: (&GetValidator{}).
+			AddMaxRate("", 1, ":6060"),
+		fmt.Sprintf(MsgControlAddressInvalid, "6060", "address 6060: missing port in address"): (&GetValidator{}).
+			AddMaxRate("10/s", 1, "6060"),
+	}
+
+	for expectedErrMessage, validator := range givenValidators {
+		var validatorEntity = validator.AddRequestCount(1).
+			AddThreads(1).
+			AddUrl("http://localhost:8080/users").
+			WithAppConfiguration(&app.Configuration{}).
+			Entity()
+
+		var actualValidationResult = validatorEntity.Validate()
+
+		if actualValidationResult.valid || strings.Join(actualValidationResult.errMessages, ",") != expectedErrMessage {
+			t.Errorf("Unexpected validation result %v, expected error: %s", actualValidationResult, expectedErrMessage)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package util
+
+import (
+	"fmt"
+)
+
+type PutValidatorBuilder interface {
+	GetValidatorBuilder
+
+	AddBody(body string) PutValidatorBuilder
+	AddContentType(contentType string) PutValidatorBuilder
+}
+
+type PutValidator struct {
+	GetValidator
+}
+
+func (b *PutValidator) AddBody(body string) PutValidatorBuilder {
+	b.entity.body = body
+	return b
+}
+
+func (b *PutValidator) AddContentType(contentType string) PutValidatorBuilder {
+	b.entity.contentType = contentType
+	return b
+}
+
+func validateBody(body string, contentType string, result *ValidationResult) {
+	if body == "" {
+		return
+	}
+
+	var bodyContent, errReadBody = ReadBody(body)
+	if errReadBody != nil {
+		result.valid = false
+		result.errMessages = append(result.errMessages, fmt.Sprintf(MsgCantReadBodyWithReason, body, errReadBody.Error()))
+		return
+	}
+
+	if result.conf != nil {
+		if contentType == "" {
+			contentType = DefaultBodyContentType
+		}
+
+		result.conf.Request.Body = bodyContent
+		result.conf.Request.BodyTemplated = ContainsTemplatePlaceholders(string(bodyContent)) || ContainsFunctions(string(bodyContent))
+		result.conf.Request.ContentType = contentType
+	}
+}
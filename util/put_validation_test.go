@@ -0,0 +1,93 @@
+package util
+
+import (
+	"github.com/vkrava4/curlson/app"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateInlineBodyWithOkOtherFlags(t *testing.T) {
+	var givenUrl = "http://localhost:8080"
+	var givenBody = `{"name":"curlson"}`
+	var givenContentType = "application/json"
+
+	var putValidator = &PutValidator{}
+	var appConf = &app.Configuration{}
+	var validatorEntity = putValidator.AddBody(givenBody).
+		AddContentType(givenContentType).
+		AddRequestCount(1).
+		AddThreads(1).
+		AddUrl(givenUrl).
+		WithAppConfiguration(appConf).
+		Entity()
+
+	var actualValidationResult = validatorEntity.Validate()
+
+	if !actualValidationResult.valid || len(actualValidationResult.errMessages) > 0 {
+		t.Errorf("Unexpected validation result %v", actualValidationResult)
+	}
+
+	if string(appConf.Request.Body) != givenBody || appConf.Request.ContentType != givenContentType {
+		t.Errorf("Unexpected app request configuration %v", appConf.Request)
+	}
+}
+
+func TestValidateBodyFromFileWithDefaultContentType(t *testing.T) {
+	var givenUrl = "http://localhost:8080"
+	var givenBodyFile = "test_body.file"
+	var testFileAbsPath, _ = filepath.Abs(givenBodyFile)
+	_ = ioutil.WriteFile(testFileAbsPath, []byte("name=curlson"), filesMode)
+	defer os.Remove(testFileAbsPath)
+
+	var putValidator = &PutValidator{}
+	var appConf = &app.Configuration{}
+	var validatorEntity = putValidator.AddBody("@" + givenBodyFile).
+		AddRequestCount(1).
+		AddThreads(1).
+		AddUrl(givenUrl).
+		WithAppConfiguration(appConf).
+		Entity()
+
+	var actualValidationResult = validatorEntity.Validate()
+
+	if !actualValidationResult.valid || len(actualValidationResult.errMessages) > 0 {
+		t.Errorf("Unexpected validation result %v", actualValidationResult)
+	}
+
+	if string(appConf.Request.Body) != "name=curlson" || appConf.Request.ContentType != DefaultBodyContentType {
+		t.Errorf("Unexpected app request configuration %v", appConf.Request)
+	}
+}
+
+func TestValidateBodyFromNonExistingFile(t *testing.T) {
+	var givenBody = "@test_body_NOT_FOUND.file"
+
+	var putValidator = &PutValidator{}
+	var validatorEntity = putValidator.AddBody(givenBody).
+		AddRequestCount(1).
+		AddThreads(1).
+		AddUrl("http://localhost:8080").
+		Entity()
+
+	var actualValidationResult = validatorEntity.Validate()
+
+	if actualValidationResult.valid || len(actualValidationResult.errMessages) != 1 ||
+		!strings.HasPrefix(actualValidationResult.errMessages[0], "Provided request body '@test_body_NOT_FOUND.file' can not be read") {
+		t.Errorf("Unexpected validation result %v", actualValidationResult)
+	}
+}
+
+func TestReadBodyFromStdin(t *testing.T) {
+	var originalStdin = bodyStdin
+	bodyStdin = strings.NewReader("from stdin")
+	defer func() { bodyStdin = originalStdin }()
+
+	var body, errReadBody = ReadBody("@-")
+
+	if errReadBody != nil || string(body) != "from stdin" {
+		t.Errorf("Unexpected body read from stdin: '%s', %v", body, errReadBody)
+	}
+}
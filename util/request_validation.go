@@ -0,0 +1,62 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+)
+
+// httpTokenCharacters contains non-alphanumeric characters allowed in an HTTP method name (see RFC 7230 token)
+const httpTokenCharacters = "!#$%&'*+-.^_`|~"
+
+type RequestValidatorBuilder interface {
+	PutValidatorBuilder
+
+	AddMethod(method string) RequestValidatorBuilder
+}
+
+type RequestValidator struct {
+	PutValidator
+}
+
+func (b *RequestValidator) AddMethod(method string) RequestValidatorBuilder {
+	b.entity.method = method
+	return b
+}
+
+func validateMethod(method string, result *ValidationResult) {
+	if method == "" {
+		return
+	}
+
+	if !IsValidMethod(method) {
+		result.valid = false
+		result.errMessages = append(result.errMessages, fmt.Sprintf(MsgMethodInvalid, method, httpTokenCharacters))
+		return
+	}
+
+	if result.conf != nil {
+		result.conf.Request.Method = method
+	}
+}
+
+// IsValidMethod returns a boolean indicating whether a given string can be used as an HTTP method name.
+// Besides standard methods such as GET or POST any custom method consisting of token characters is valid
+func IsValidMethod(method string) bool {
+	return isHTTPToken(method)
+}
+
+// isHTTPToken returns a boolean indicating whether a given string is a non-empty RFC 7230 token
+func isHTTPToken(value string) bool {
+	if value == "" {
+		return false
+	}
+
+	for _, character := range value {
+		var alphanumeric = character >= 'a' && character <= 'z' || character >= 'A' && character <= 'Z' || character >= '0' && character <= '9'
+		if !alphanumeric && !strings.ContainsRune(httpTokenCharacters, character) {
+			return false
+		}
+	}
+
+	return true
+}
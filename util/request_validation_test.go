@@ -0,0 +1,64 @@
+package util
+
+import (
+	"fmt"
+	"github.com/vkrava4/curlson/app"
+	"strings"
+	"testing"
+)
+
+func TestValidateCustomMethodWithOkOtherFlags(t *testing.T) {
+	var givenMethod = "PURGE"
+
+	var requestValidator = &RequestValidator{}
+	var appConf = &app.Configuration{}
+	var validatorEntity = requestValidator.AddMethod(givenMethod).
+		AddBody("").
+		AddRequestCount(1).
+		AddThreads(1).
+		AddUrl("http://localhost:8080").
+		WithAppConfiguration(appConf).
+		Entity()
+
+	var actualValidationResult = validatorEntity.Validate()
+
+	if !actualValidationResult.valid || len(actualValidationResult.errMessages) > 0 {
+		t.Errorf("Unexpected validation result %v", actualValidationResult)
+	}
+
+	if appConf.Request.Method != givenMethod {
+		t.Errorf("Unexpected app request configuration %v", appConf.Request)
+	}
+}
+
+func TestValidateInvalidMethodWithOkOtherFlags(t *testing.T) {
+	var givenMethod = "GET /"
+
+	var requestValidator = &RequestValidator{}
+	var validatorEntity = requestValidator.AddMethod(givenMethod).
+		AddRequestCount(1).
+		AddThreads(1).
+		AddUrl("http://localhost:8080").
+		Entity()
+
+	var actualValidationResult = validatorEntity.Validate()
+
+	if actualValidationResult.valid || strings.Join(actualValidationResult.errMessages, ",") !=
+		fmt.Sprintf(MsgMethodInvalid, givenMethod, httpTokenCharacters) {
+		t.Errorf("Unexpected validation result %v", actualValidationResult)
+	}
+}
+
+func TestIsValidMethod(t *testing.T) {
+	for _, method := range []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS", "PURGE", "M-SEARCH", "custom_1"} {
+		if !IsValidMethod(method) {
+			t.Errorf("Method '%s' should be valid", method)
+		}
+	}
+
+	for _, method := range []string{"", "GET POST", "GET\n", "GÉT", "(GET)"} {
+		if IsValidMethod(method) {
+			t.Errorf("Method '%s' should be invalid", method)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package util
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// DefaultTemplateDelimiter is a delimiter of values of template lines when it's not specified explicitly
+const DefaultTemplateDelimiter = ','
+
+// TemplateRecord holds values of a single template line which are referenced by placeholder keys
+type TemplateRecord interface {
+
+	// Value returns a value by a given placeholder key. The returned boolean is false when there is no such value
+	Value(key string) (string, bool)
+
+	// String returns a textual representation of the record for logging purposes
+	String() string
+
+	// Data returns values of the record for Go templates
+	Data() interface{}
+}
+
+// CSVRecord holds values of a single CSV template line. Values can be referenced by their index
+// or, when a template file has a header row, by a name of their column
+type CSVRecord struct {
+	Values []string
+	Names  map[string]int
+}
+
+// ParseCSVRecord parses a given template line as a CSV record (RFC 4180) with a given delimiter.
+// Given column names, if any, are shared by all records of a template
+func ParseCSVRecord(line string, delimiter rune, names map[string]int) (*CSVRecord, error) {
+	var values, errParse = parseCSVLine(line, delimiter)
+	if errParse != nil {
+		return nil, errParse
+	}
+
+	return &CSVRecord{Values: values, Names: names}, nil
+}
+
+// ParseTemplateHeader parses a given template header line into a mapping of column names to their indexes
+func ParseTemplateHeader(line string, delimiter rune) (map[string]int, error) {
+	var columns, errParse = parseCSVLine(line, delimiter)
+	if errParse != nil {
+		return nil, errParse
+	}
+
+	var names = make(map[string]int, len(columns))
+	for i, column := range columns {
+		var name = strings.TrimSpace(column)
+		switch {
+		case name == "":
+			return nil, fmt.Errorf("Template header column %d has an empty name", i)
+
+		case !templatePlaceholderNameRegex.MatchString(name):
+			return nil, fmt.Errorf("Template header column '%s' should consist of letters, digits or any of: _.-", name)
+		}
+
+		if _, exists := names[name]; exists {
+			return nil, fmt.Errorf("Template header column '%s' is duplicated", name)
+		}
+		names[name] = i
+	}
+
+	return names, nil
+}
+
+// ParseTemplateDelimiter parses a given delimiter of template values. It should be a single character,
+// a tab character can be also given as '\t' or 'tab'
+func ParseTemplateDelimiter(delimiter string) (rune, error) {
+	if delimiter == "\\t" || strings.EqualFold(delimiter, "tab") {
+		return '\t', nil
+	}
+
+	var runes = []rune(delimiter)
+	if len(runes) != 1 {
+		return 0, errors.New("it should be a single character")
+	}
+
+	var reader = csv.NewReader(strings.NewReader(""))
+	reader.Comma = runes[0]
+	if _, errRead := reader.Read(); errRead != nil && errRead != io.EOF {
+		return 0, errors.New("it can't be a quote, a line break or the Unicode replacement character")
+	}
+	return runes[0], nil
+}
+
+// Value returns a value of the record by a given placeholder key which is either an index of the value
+// or a name of its column. The returned boolean is false when there is no such value
+func (r *CSVRecord) Value(key string) (string, bool) {
+	var index, errIndex = strconv.Atoi(key)
+	if errIndex != nil {
+		var namedIndex, exists = r.Names[key]
+		if !exists {
+			return "", false
+		}
+		index = namedIndex
+	}
+
+	if index < 0 || index >= len(r.Values) {
+		return "", false
+	}
+	return r.Values[index], true
+}
+
+// Data returns a map of values of the record by their column names, if any, and indexes
+func (r *CSVRecord) Data() interface{} {
+	var data = make(map[string]string, len(r.Values)+len(r.Names))
+	for i, value := range r.Values {
+		data[strconv.Itoa(i)] = value
+	}
+
+	for name, index := range r.Names {
+		if index < len(r.Values) {
+			data[name] = r.Values[index]
+		}
+	}
+	return data
+}
+
+// String returns values of the record joined with a comma for logging purposes
+func (r *CSVRecord) String() string {
+	return strings.Join(r.Values, ",")
+}
+
+func parseCSVLine(line string, delimiter rune) ([]string, error) {
+	var reader = csv.NewReader(strings.NewReader(line))
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+
+	var values, errRead = reader.Read()
+	switch {
+	case errRead == io.EOF:
+		return []string{""}, nil
+
+	case errRead != nil:
+		return nil, fmt.Errorf("Template line '%s' is not a valid CSV record. Reason: %s", line, errRead.Error())
+	}
+
+	return values, nil
+}
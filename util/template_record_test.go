@@ -0,0 +1,82 @@
+package util
+
+import (
+	"reflect"
+	"testing"
+)
+
+func givenTemplateRecord(line string) TemplateRecord {
+	var record, _ = ParseCSVRecord(line, DefaultTemplateDelimiter, nil)
+	return record
+}
+
+func TestParseCSVRecordWithQuotedValues(t *testing.T) {
+	var record, errParse = ParseCSVRecord(`1;"Doe; John";"say ""hi"""`, ';', nil)
+
+	var expected = []string{"1", "Doe; John", `say "hi"`}
+	if errParse != nil || !reflect.DeepEqual(record.Values, expected) {
+		t.Errorf("Unexpected record values, actual: %v (%v), expected: %v", record, errParse, expected)
+	}
+}
+
+func TestParseInvalidTemplateRecord(t *testing.T) {
+	if _, errParse := ParseCSVRecord(`1,"unterminated`, DefaultTemplateDelimiter, nil); errParse == nil {
+		t.Error("An error is expected for a line with an unterminated quote")
+	}
+}
+
+func TestTemplateRecordValueByNameAndIndex(t *testing.T) {
+	var names, errHeader = ParseTemplateHeader("user_id, query", DefaultTemplateDelimiter)
+	if errHeader != nil {
+		t.Fatalf("Unexpected error: %v", errHeader)
+	}
+
+	var record, _ = ParseCSVRecord("42,a b", DefaultTemplateDelimiter, names)
+	var expectations = map[string]string{"user_id": "42", "query": "a b", "0": "42", "1": "a b"}
+	for key, expected := range expectations {
+		if actual, exists := record.Value(key); !exists || actual != expected {
+			t.Errorf("Unexpected value for key '%s', actual: '%s', expected: '%s'", key, actual, expected)
+		}
+	}
+
+	for _, key := range []string{"missing", "2", "-1"} {
+		if _, exists := record.Value(key); exists {
+			t.Errorf("Value for key '%s' should not exist", key)
+		}
+	}
+}
+
+func TestParseInvalidTemplateHeader(t *testing.T) {
+	for _, given := range []string{"id,,name", "id,id", "id,user name"} {
+		if _, errHeader := ParseTemplateHeader(given, DefaultTemplateDelimiter); errHeader == nil {
+			t.Errorf("An error is expected for header '%s'", given)
+		}
+	}
+}
+
+func TestParseTemplateDelimiter(t *testing.T) {
+	var expectations = map[string]rune{";": ';', "\\t": '\t', "tab": '\t', "|": '|'}
+	for given, expected := range expectations {
+		if actual, errParse := ParseTemplateDelimiter(given); errParse != nil || actual != expected {
+			t.Errorf("Unexpected delimiter for '%s', actual: %q (%v), expected: %q", given, actual, errParse, expected)
+		}
+	}
+
+	for _, given := range []string{"", ";;", "\"", "\n"} {
+		if _, errParse := ParseTemplateDelimiter(given); errParse == nil {
+			t.Errorf("An error is expected for delimiter '%s'", given)
+		}
+	}
+}
+
+func TestReplaceNamedPlaceholders(t *testing.T) {
+	var names, _ = ParseTemplateHeader("user_id,query", DefaultTemplateDelimiter)
+	var record, _ = ParseCSVRecord(`42,"a,b"`, DefaultTemplateDelimiter, names)
+
+	var actual = ReplacePlaceholders("/users/#T{user_id}?q=#TE{query}&raw=#T{1}&x=#T{other}", record)
+
+	var expected = "/users/42?q=a%2Cb&raw=a,b&x=#T{other}"
+	if actual != expected {
+		t.Errorf("ReplacePlaceholders result is incorrect, actual: '%s', expected: '%s'", actual, expected)
+	}
+}
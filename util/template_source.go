@@ -0,0 +1,353 @@
+package util
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// TemplateInMemoryLimit is a maximum size of a template file in bytes which is loaded into memory entirely.
+// Bigger files are indexed by line offsets and their lines are read from disk on demand
+const TemplateInMemoryLimit int64 = 64 * 1024 * 1024
+
+// stringHeaderSize is a size of a string header in bytes used to estimate a memory footprint of loaded lines
+const stringHeaderSize = 16
+
+// offsetSize is a size of a single line offset in bytes used to estimate a memory footprint of an index
+const offsetSize = 8
+
+const (
+	// TemplateTypeCSV is a type of template files which lines are CSV records
+	TemplateTypeCSV = "csv"
+
+	// TemplateTypeJSONL is a type of template files which lines are JSON values (JSON Lines)
+	TemplateTypeJSONL = "jsonl"
+)
+
+var templateTypes = []string{TemplateTypeCSV, TemplateTypeJSONL}
+
+// TemplateTypeForPath returns a template type based on an extension of a given file path:
+// JSON Lines for '.jsonl' and '.ndjson', CSV otherwise
+func TemplateTypeForPath(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jsonl", ".ndjson":
+		return TemplateTypeJSONL
+	default:
+		return TemplateTypeCSV
+	}
+}
+
+// TemplateFormat describes how lines of a template file are parsed into records
+type TemplateFormat struct {
+	// Type is either TemplateTypeCSV or TemplateTypeJSONL
+	Type string
+
+	// Delimiter separates values of a template line
+	Delimiter rune
+
+	// Header defines whether the first line of a CSV template file holds names of columns
+	Header bool
+}
+
+// TemplateSource provides random access to lines of a template file. It's loaded or indexed once
+// and is safe for a concurrent use by multiple goroutines
+type TemplateSource struct {
+	path   string
+	format TemplateFormat
+
+	// names are column names from a template header row, if any
+	names map[string]int
+
+	// lines holds all template lines when the template is loaded into memory
+	lines []string
+
+	// file and offsets are used when the template is too big for memory: offsets[i] is a start of the line 'i'
+	// and the last offset is a size of the file
+	file    *os.File
+	offsets []int64
+
+	indexDuration time.Duration
+	footprint     int64
+}
+
+// OpenTemplateSource loads a template file with a given path into memory when its size doesn't exceed a given limit,
+// otherwise builds a line offsets index of the file. Lines are separated by '\n', a trailing empty line is ignored.
+// Line breaks inside quoted values of CSV templates don't separate lines, so a single template line can span
+// multiple lines of the file. When a given CSV format has a header, the first line is parsed into column names
+// and is not counted as a template line
+func OpenTemplateSource(path string, inMemoryLimit int64, format TemplateFormat) (*TemplateSource, error) {
+	var startTime = time.Now()
+	var fileInfo, errStat = os.Stat(path)
+	if errStat != nil {
+		return nil, errStat
+	}
+
+	var source = &TemplateSource{path: path, format: format}
+	if fileInfo.Size() <= inMemoryLimit {
+		var content, errRead = ioutil.ReadFile(path)
+		if errRead != nil {
+			return nil, errRead
+		}
+
+		source.lines = splitTemplateLines(string(content), newLineScanner(format))
+		source.footprint = int64(len(content)) + int64(len(source.lines))*stringHeaderSize
+	} else {
+		var file, errOpen = os.OpenFile(path, os.O_RDONLY, filesMode)
+		if errOpen != nil {
+			return nil, errOpen
+		}
+
+		var offsets, errIndex = indexTemplateLines(file, newLineScanner(format))
+		if errIndex != nil {
+			_ = file.Close()
+			return nil, errIndex
+		}
+
+		source.file = file
+		source.offsets = offsets
+		source.footprint = int64(cap(offsets)) * offsetSize
+	}
+
+	if format.Header && format.Type != TemplateTypeJSONL {
+		if errHeader := source.readHeader(); errHeader != nil {
+			_ = source.Close()
+			return nil, errHeader
+		}
+	}
+
+	source.indexDuration = time.Since(startTime)
+	return source, nil
+}
+
+// lineScanner finds line breaks which separate template lines. Line breaks inside quoted values of CSV templates
+// are skipped following the same quoting rules as encoding/csv: a quote opens a quoted value only at a start of a value
+// and two quotes inside a quoted value stand for a single quote
+type lineScanner struct {
+	csv       bool
+	delimiter []byte
+
+	matched    int
+	valueStart bool
+	quoted     bool
+	quote      bool
+}
+
+func newLineScanner(format TemplateFormat) *lineScanner {
+	return &lineScanner{
+		csv:        format.Type != TemplateTypeJSONL,
+		delimiter:  []byte(string(format.Delimiter)),
+		valueStart: true,
+	}
+}
+
+// endsLine returns a boolean indicating whether a given next byte of a template file ends a template line
+func (s *lineScanner) endsLine(b byte) bool {
+	if !s.csv {
+		return b == filesEndLineDelimiter
+	}
+
+	if s.quoted {
+		switch {
+		case s.quote && b == '"':
+			s.quote = false
+			return false
+		case s.quote:
+			s.quote, s.quoted = false, false
+		case b == '"':
+			s.quote = true
+			return false
+		default:
+			return false
+		}
+	}
+
+	switch {
+	case b == filesEndLineDelimiter:
+		s.matched, s.valueStart = 0, true
+		return true
+
+	case len(s.delimiter) > 0 && b == s.delimiter[s.matched]:
+		s.matched++
+		if s.matched == len(s.delimiter) {
+			s.matched, s.valueStart = 0, true
+		}
+		return false
+
+	case b == '"' && s.valueStart:
+		s.quoted, s.valueStart = true, false
+
+	default:
+		s.valueStart = false
+	}
+
+	s.matched = 0
+	return false
+}
+
+// splitTemplateLines splits a given template content into lines found by a given scanner
+func splitTemplateLines(content string, scanner *lineScanner) []string {
+	if content == "" {
+		return nil
+	}
+
+	var lines []string
+	var start = 0
+	for i := 0; i < len(content); i++ {
+		if scanner.endsLine(content[i]) {
+			lines = append(lines, content[start:i])
+			start = i + 1
+		}
+	}
+
+	if start < len(content) {
+		lines = append(lines, content[start:])
+	}
+	return lines
+}
+
+// indexTemplateLines reads a given file sequentially and collects start offsets of all its lines found by a given scanner
+func indexTemplateLines(file *os.File, scanner *lineScanner) ([]int64, error) {
+	var reader = bufio.NewReaderSize(file, fileBuffer)
+	var buffer = make([]byte, fileBuffer)
+	var offsets = []int64{0}
+	var position int64
+
+	for {
+		var c, errRead = reader.Read(buffer)
+		for i := 0; i < c; i++ {
+			if scanner.endsLine(buffer[i]) {
+				offsets = append(offsets, position+int64(i)+1)
+			}
+		}
+		position += int64(c)
+
+		switch {
+		case errRead == io.EOF:
+			if offsets[len(offsets)-1] != position {
+				offsets = append(offsets, position)
+			}
+			return offsets, nil
+
+		case errRead != nil:
+			return nil, errRead
+		}
+	}
+}
+
+// readHeader parses the first line of the template into column names
+func (s *TemplateSource) readHeader() error {
+	if s.rawSize() == 0 {
+		return fmt.Errorf("Template '%s' has no header line", s.path)
+	}
+
+	var headerLine, errLine = s.rawLine(0)
+	if errLine != nil {
+		return errLine
+	}
+
+	var names, errHeader = ParseTemplateHeader(headerLine, s.format.Delimiter)
+	if errHeader != nil {
+		return errHeader
+	}
+
+	s.names = names
+	return nil
+}
+
+// rawSize returns a number of lines of the template file including a header line
+func (s *TemplateSource) rawSize() int {
+	if s.file != nil {
+		return len(s.offsets) - 1
+	}
+	return len(s.lines)
+}
+
+// Size returns a number of lines of the template excluding a header line
+func (s *TemplateSource) Size() int {
+	if s.names != nil {
+		return s.rawSize() - 1
+	}
+	return s.rawSize()
+}
+
+// Names returns column names from a template header row or nil when the template has no header
+func (s *TemplateSource) Names() map[string]int {
+	return s.names
+}
+
+// InMemory returns a boolean indicating whether the template is loaded into memory entirely
+func (s *TemplateSource) InMemory() bool {
+	return s.file == nil
+}
+
+// IndexDuration returns how long it took to load or index the template
+func (s *TemplateSource) IndexDuration() time.Duration {
+	return s.indexDuration
+}
+
+// Footprint returns an estimated memory footprint of loaded lines or line offsets index in bytes
+func (s *TemplateSource) Footprint() int64 {
+	return s.footprint
+}
+
+// Line returns a template line with a given number starting from zero. A header line is not counted
+func (s *TemplateSource) Line(lineNumber int) (string, error) {
+	if lineNumber < 0 || lineNumber >= s.Size() {
+		return "", fmt.Errorf("Line number %d is out of range of template '%s' with %d lines", lineNumber, s.path, s.Size())
+	}
+
+	if s.names != nil {
+		lineNumber++
+	}
+	return s.rawLine(lineNumber)
+}
+
+// Record returns a template line with a given number parsed into a record
+func (s *TemplateSource) Record(lineNumber int) (TemplateRecord, error) {
+	var line, errLine = s.Line(lineNumber)
+	if errLine != nil {
+		return nil, errLine
+	}
+
+	if s.format.Type == TemplateTypeJSONL {
+		var record, errParse = ParseJSONRecord(line)
+		if errParse != nil {
+			return nil, errParse
+		}
+		return record, nil
+	}
+
+	var record, errParse = ParseCSVRecord(line, s.format.Delimiter, s.names)
+	if errParse != nil {
+		return nil, errParse
+	}
+	return record, nil
+}
+
+// rawLine returns a line of the template file with a given number counting a header line
+func (s *TemplateSource) rawLine(lineNumber int) (string, error) {
+	if s.file == nil {
+		return s.lines[lineNumber], nil
+	}
+
+	var start, end = s.offsets[lineNumber], s.offsets[lineNumber+1]
+	var line = make([]byte, end-start)
+	if _, errRead := s.file.ReadAt(line, start); errRead != nil && errRead != io.EOF {
+		return "", errRead
+	}
+
+	return strings.TrimSuffix(string(line), string(filesEndLineDelimiter)), nil
+}
+
+// Close releases the template file when it's read from disk
+func (s *TemplateSource) Close() error {
+	if s.file != nil {
+		return s.file.Close()
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package util
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestOpenTemplateSourceInMemory(t *testing.T) {
+	var testFileAbsPath, _ = filepath.Abs("test_template.file")
+	_ = ioutil.WriteFile(testFileAbsPath, []byte("a,1\n\nc,3\n"), filesMode)
+	defer os.Remove(testFileAbsPath)
+
+	var source, errOpen = OpenTemplateSource(testFileAbsPath, TemplateInMemoryLimit, TemplateFormat{Type: TemplateTypeCSV, Delimiter: DefaultTemplateDelimiter})
+	if errOpen != nil {
+		t.Fatalf("Unexpected error: %v", errOpen)
+	}
+	defer source.Close()
+
+	assertTemplateLines(t, source, []string{"a,1", "", "c,3"})
+	if !source.InMemory() || source.Footprint() <= 0 {
+		t.Errorf("Unexpected template source state, in memory: %v, footprint: %d", source.InMemory(), source.Footprint())
+	}
+}
+
+func TestOpenTemplateSourceIndexed(t *testing.T) {
+	var testFileAbsPath, _ = filepath.Abs("test_template.file")
+	_ = ioutil.WriteFile(testFileAbsPath, []byte("a,1\n\nc,3"), filesMode)
+	defer os.Remove(testFileAbsPath)
+
+	var source, errOpen = OpenTemplateSource(testFileAbsPath, 0, TemplateFormat{Type: TemplateTypeCSV, Delimiter: DefaultTemplateDelimiter})
+	if errOpen != nil {
+		t.Fatalf("Unexpected error: %v", errOpen)
+	}
+	defer source.Close()
+
+	assertTemplateLines(t, source, []string{"a,1", "", "c,3"})
+	if source.InMemory() {
+		t.Error("Template source should not be loaded into memory")
+	}
+
+	if _, errLine := source.Line(3); errLine == nil {
+		t.Error("An error is expected for a line out of range")
+	}
+}
+
+func TestTemplateSourceConcurrentLines(t *testing.T) {
+	var testFileAbsPath, _ = filepath.Abs("test_template.file")
+	_ = ioutil.WriteFile(testFileAbsPath, []byte("0\n1\n2\n3\n"), filesMode)
+	defer os.Remove(testFileAbsPath)
+
+	var source, _ = OpenTemplateSource(testFileAbsPath, 0, TemplateFormat{Type: TemplateTypeCSV, Delimiter: DefaultTemplateDelimiter})
+	defer source.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		var i = i
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				var lineNumber = (i + j) % source.Size()
+				var line, errLine = source.Line(lineNumber)
+				if errLine != nil || line != string(rune('0'+lineNumber)) {
+					t.Errorf("Unexpected random line %d: '%s', %v", lineNumber, line, errLine)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func assertTemplateLines(t *testing.T, source *TemplateSource, expected []string) {
+	if source.Size() != len(expected) {
+		t.Fatalf("Unexpected template size, actual: %d, expected: %d", source.Size(), len(expected))
+	}
+
+	for i, expectedLine := range expected {
+		if actualLine, errLine := source.Line(i); errLine != nil || actualLine != expectedLine {
+			t.Errorf("Unexpected line %d, actual: '%s' (%v), expected: '%s'", i, actualLine, errLine, expectedLine)
+		}
+	}
+}
+
+func TestOpenTemplateSourceWithHeader(t *testing.T) {
+	var testFileAbsPath, _ = filepath.Abs("test_template.file")
+	_ = ioutil.WriteFile(testFileAbsPath, []byte("id|name\n1|\"Doe| John\"\n2|Bob\n"), filesMode)
+	defer os.Remove(testFileAbsPath)
+
+	for _, inMemoryLimit := range []int64{TemplateInMemoryLimit, 0} {
+		var source, errOpen = OpenTemplateSource(testFileAbsPath, inMemoryLimit, TemplateFormat{Type: TemplateTypeCSV, Delimiter: '|', Header: true})
+		if errOpen != nil {
+			t.Fatalf("Unexpected error: %v", errOpen)
+		}
+
+		var record, errRecord = source.Record(0)
+		if name, _ := record.Value("name"); errRecord != nil || source.Size() != 2 || name != "Doe| John" {
+			t.Errorf("Unexpected first record %v (%v) of template with %d lines", record, errRecord, source.Size())
+		}
+		_ = source.Close()
+	}
+}
+
+func TestOpenTemplateSourceWithQuotedLineBreaks(t *testing.T) {
+	var testFileAbsPath, _ = filepath.Abs("test_template.file")
+	_ = ioutil.WriteFile(testFileAbsPath, []byte("id;note\n1;\"first\nsecond\"\n2;\"say \"\"hi\n\"\"\";x\n3;a\"b\n"), filesMode)
+	defer os.Remove(testFileAbsPath)
+
+	for _, inMemoryLimit := range []int64{TemplateInMemoryLimit, 0} {
+		var source, errOpen = OpenTemplateSource(testFileAbsPath, inMemoryLimit, TemplateFormat{Type: TemplateTypeCSV, Delimiter: ';', Header: true})
+		if errOpen != nil {
+			t.Fatalf("Unexpected error: %v", errOpen)
+		}
+
+		assertTemplateLines(t, source, []string{"1;\"first\nsecond\"", "2;\"say \"\"hi\n\"\"\";x", "3;a\"b"})
+
+		var record, errRecord = source.Record(0)
+		if note, _ := record.Value("note"); errRecord != nil || note != "first\nsecond" {
+			t.Errorf("Unexpected first record %v (%v)", record, errRecord)
+		}
+		_ = source.Close()
+	}
+}
+
+func TestOpenTemplateSourceJSONLIgnoresQuotes(t *testing.T) {
+	var testFileAbsPath, _ = filepath.Abs("test_template.file")
+	_ = ioutil.WriteFile(testFileAbsPath, []byte("\"open\n{\"id\": 1}\n"), filesMode)
+	defer os.Remove(testFileAbsPath)
+
+	for _, inMemoryLimit := range []int64{TemplateInMemoryLimit, 0} {
+		var source, errOpen = OpenTemplateSource(testFileAbsPath, inMemoryLimit, TemplateFormat{Type: TemplateTypeJSONL})
+		if errOpen != nil {
+			t.Fatalf("Unexpected error: %v", errOpen)
+		}
+
+		assertTemplateLines(t, source, []string{"\"open", "{\"id\": 1}"})
+		_ = source.Close()
+	}
+}
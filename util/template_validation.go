@@ -0,0 +1,331 @@
+package util
+
+import (
+	"fmt"
+	"github.com/vkrava4/curlson/app"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// validateTemplateEngine checks template functions of a given request for 'curlson' engine or compiles it for 'go' engine.
+// It returns a request template to validate against a template file or nil when the request can't be compiled
+func validateTemplateEngine(engine string, request *templatedRequest, result *ValidationResult) requestTemplate {
+	if engine == "" {
+		engine = TemplateEngineCurlson
+	}
+	validateOneOf("Template engine", engine, templateEngines, result)
+
+	if engine != TemplateEngineGo {
+		if errFunctions := request.validateFunctions(); errFunctions != nil {
+			result.valid = false
+			result.errMessages = append(result.errMessages, errFunctions.Error())
+		}
+		return request.resolveFunctions(&FunctionContext{})
+	}
+
+	var goTemplate, errCompile = CompileGoTemplate(request.url, request.headers, request.body)
+	if errCompile != nil {
+		result.valid = false
+		result.errMessages = append(result.errMessages, fmt.Sprintf(MsgGoTemplateInvalidWithReason, errCompile.Error()))
+		return nil
+	}
+
+	if result.conf != nil {
+		result.conf.Request.GoTemplate = goTemplate
+	}
+	return newGoTemplateRenderer(request.url, goTemplate, request.headers, request.body != nil)
+}
+
+// validateDataSources builds configurations of a template file and named data sources. Every source is selected
+// with a given template mode unless a data mode 'name=mode' is provided for it
+func validateDataSources(template string, mode string, dataSources []string, dataModes []string, result *ValidationResult) []*app.TemplateSourceConfiguration {
+	if mode == "" {
+		mode = TemplateModeRandom
+	}
+	validateOneOf("Template mode", mode, templateModes, result)
+
+	var sources []*app.TemplateSourceConfiguration
+	if template != "" {
+		sources = append(sources, &app.TemplateSourceConfiguration{Path: template, Mode: mode})
+	}
+
+	var namedSources = make(map[string]*app.TemplateSourceConfiguration)
+	for _, definition := range dataSources {
+		var name, path, errParse = ParseDataSource(definition)
+		if errParse != nil {
+			result.valid = false
+			result.errMessages = append(result.errMessages, fmt.Sprintf(MsgDataSourceInvalidWithReason, definition, errParse.Error()))
+			continue
+		}
+
+		if namedSources[name] != nil {
+			result.valid = false
+			result.errMessages = append(result.errMessages, fmt.Sprintf(MsgDataSourceDuplicate, name))
+			continue
+		}
+
+		namedSources[name] = &app.TemplateSourceConfiguration{Name: name, Path: path, Mode: mode}
+		sources = append(sources, namedSources[name])
+	}
+
+	for _, definition := range dataModes {
+		var separator = strings.Index(definition, "=")
+		if separator < 0 {
+			result.valid = false
+			result.errMessages = append(result.errMessages, fmt.Sprintf(MsgDataModeInvalid, definition))
+			continue
+		}
+
+		var name, sourceMode = strings.TrimSpace(definition[:separator]), strings.TrimSpace(definition[separator+1:])
+		var source = namedSources[name]
+		if source == nil {
+			result.valid = false
+			result.errMessages = append(result.errMessages, fmt.Sprintf(MsgDataModeSourceNotFound, definition, name))
+			continue
+		}
+
+		validateOneOf(fmt.Sprintf("Template mode of data source '%s'", name), sourceMode, templateModes, result)
+		source.Mode = sourceMode
+	}
+
+	return sources
+}
+
+// validateTemplateMode stores a seed of random values and checks modes of validated template sources against amounts of threads and requests
+func validateTemplateMode(seed int64, threads int, totalRequests int, result *ValidationResult) {
+	if result.conf == nil {
+		return
+	}
+
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	result.conf.Template.Seed = seed
+
+	for _, source := range result.conf.Template.Sources {
+		switch {
+		case source.Mode == TemplateModeOnce && totalRequests > source.Size:
+			result.warnMessages = append(result.warnMessages, sourceMessage(source.Name, fmt.Sprintf(MsgTemplateOnceExhausted, source.Size, totalRequests)))
+
+		case source.Mode == TemplateModePerThread && threads > source.Size:
+			result.valid = false
+			result.errMessages = append(result.errMessages, sourceMessage(source.Name, fmt.Sprintf(MsgTemplatePerThreadTooSmall, source.Size, threads)))
+		}
+	}
+}
+
+// sourceMessage prefixes a validation message with a name of a data source. Messages of the unnamed template file are kept as is
+func sourceMessage(name string, message string) string {
+	if name == "" {
+		return message
+	}
+	return fmt.Sprintf(MsgDataSourcePrefix, name, message)
+}
+
+// validateUrlForTemplate checks request parts against lines of every given template source and stores
+// the sources into app configuration when all of them are valid. Every line of a source is checked along with
+// the first lines of other sources only, as checking all combinations of lines is not feasible. So an error which
+// occurs only for a particular combination of lines of different sources is not found until execution
+func validateUrlForTemplate(sources []*app.TemplateSourceConfiguration, format TemplateFormat, request requestTemplate, result *ValidationResult) {
+	if len(sources) == 0 {
+		request.validateWithoutRecord(result)
+		return
+	}
+
+	var templateSources = make([]*TemplateSource, 0, len(sources))
+	defer func() {
+		for _, templateSource := range templateSources {
+			_ = templateSource.Close()
+		}
+	}()
+
+	for _, source := range sources {
+		var templateSource = openTemplateSource(source, format, result)
+		if templateSource != nil {
+			templateSources = append(templateSources, templateSource)
+		}
+	}
+
+	if len(templateSources) != len(sources) {
+		return
+	}
+
+	if !request.containsTemplatePlaceholders() {
+		result.warnMessages = append(result.warnMessages, fmt.Sprintf(MsgURLPlaceholdersNotFound, request.templateURL()))
+		return
+	}
+
+	var sourceNames = make(map[string]bool)
+	for _, source := range sources {
+		if source.Name != "" {
+			sourceNames[source.Name] = true
+		}
+	}
+
+	for i, source := range sources {
+		if !validateSourcePlaceholderKeys(source.Name, templateSources[i], sourceNames, request, result) {
+			return
+		}
+	}
+
+	// lines of every source are validated along with the first lines of other sources, which are only samples
+	// of values the lines are joined with during execution
+	var samples = NewTemplateRecords(sourceNames)
+	for i, source := range sources {
+		if record, errRecord := templateSources[i].Record(0); errRecord == nil {
+			samples.Set(source.Name, record)
+		}
+	}
+
+	var valid = true
+	for i, source := range sources {
+		valid = validateURLForExistingTemplate(source.Name, templateSources[i], samples, request, result) && valid
+	}
+
+	if valid && result.valid && result.conf != nil {
+		for i, source := range sources {
+			source.Size = templateSources[i].Size()
+		}
+		result.conf.Template.Enabled = true
+		result.conf.Template.Sources = sources
+	}
+}
+
+// openTemplateSource opens a template file of a given source configuration with a given format. A type of the template
+// is determined by the file extension unless it's set by the format. The configuration is updated with the absolute path and format
+func openTemplateSource(source *app.TemplateSourceConfiguration, format TemplateFormat, result *ValidationResult) *TemplateSource {
+	var absTemplatePath, errAbsFile = filepath.Abs(source.Path)
+	if errAbsFile != nil {
+		result.valid = false
+		result.errMessages = append(result.errMessages, sourceMessage(source.Name, fmt.Sprintf(MsgTemplatePathInvalidWithReason, source.Path, errAbsFile.Error())))
+		return nil
+	}
+
+	if !fileExist(absTemplatePath) {
+		result.valid = false
+		result.errMessages = append(result.errMessages, sourceMessage(source.Name, fmt.Sprintf(MsgTemplateNotFound, source.Path)))
+		return nil
+	}
+
+	if format.Type == "" {
+		format.Type = TemplateTypeForPath(absTemplatePath)
+	}
+
+	var templateSource, errOpenSource = OpenTemplateSource(absTemplatePath, TemplateInMemoryLimit, format)
+	if errOpenSource != nil {
+		result.valid = false
+		result.errMessages = append(result.errMessages, sourceMessage(source.Name, fmt.Sprintf(MsgCantOpenTemplateWithReason, source.Path, errOpenSource.Error())))
+		return nil
+	}
+
+	if templateSource.Size() == 0 {
+		_ = templateSource.Close()
+		result.valid = false
+		result.errMessages = append(result.errMessages, sourceMessage(source.Name, fmt.Sprintf(MsgTemplateEmpty, source.Path)))
+		return nil
+	}
+
+	source.Path = absTemplatePath
+	source.Type = format.Type
+	source.Delimiter = format.Delimiter
+	source.Header = format.Header && format.Type != TemplateTypeJSONL
+	return templateSource
+}
+
+// validateSourcePlaceholderKeys checks placeholders referencing columns of a CSV template source with a given name by their names
+func validateSourcePlaceholderKeys(name string, templateSource *TemplateSource, sourceNames map[string]bool, request requestTemplate, result *ValidationResult) bool {
+	if templateSource.format.Type == TemplateTypeJSONL {
+		return true
+	}
+
+	var keys = sourceKeys(request.placeholderKeys(), name, sourceNames)
+	if errKeys := validatePlaceholderKeys(keys, templateSource.Names()); errKeys != nil {
+		result.valid = false
+		result.errMessages = append(result.errMessages, sourceMessage(name, errKeys.Error()))
+		return false
+	}
+	return true
+}
+
+// validateURLForExistingTemplate checks request parts against every line of a template source with a given name
+// joined with given sample records of other sources. It returns a boolean indicating whether all lines are valid
+func validateURLForExistingTemplate(name string, templateSource *TemplateSource, samples *TemplateRecords, request requestTemplate, result *ValidationResult) bool {
+	var validateRecord = request.recordValidator()
+	var invalidLines = 0
+	for i := 0; i < templateSource.Size(); i++ {
+		var record, errRecord = templateSource.Record(i)
+		if errRecord == nil {
+			errRecord = validateRecord(samples.with(name, record))
+		}
+
+		if errRecord != nil {
+			invalidLines++
+			if invalidLines <= maxReportedTemplateLines {
+				result.valid = false
+				result.errMessages = append(result.errMessages, sourceMessage(name, fmt.Sprintf(MsgTemplateLineInvalidWithReason, i+1, errRecord.Error())))
+			}
+		}
+	}
+
+	if invalidLines > maxReportedTemplateLines {
+		result.errMessages = append(result.errMessages, sourceMessage(name, fmt.Sprintf(MsgTemplateMoreLinesInvalid, invalidLines-maxReportedTemplateLines)))
+	}
+
+	return invalidLines == 0
+}
+
+// validateRecordKeys checks whether a given template record has values for all given placeholder keys
+func validateRecordKeys(record TemplateRecord, keys []string) error {
+	for _, key := range keys {
+		if _, exists := record.Value(key); !exists {
+			return fmt.Errorf(MsgTemplateValueNotFound, key)
+		}
+	}
+	return nil
+}
+
+// validatePlaceholderKeys checks whether placeholders referencing columns by name match given column names of a template header
+func validatePlaceholderKeys(keys []string, names map[string]int) error {
+	for _, key := range keys {
+		if _, errIndex := strconv.Atoi(key); errIndex == nil {
+			continue
+		}
+
+		if names == nil {
+			return fmt.Errorf(MsgTemplateHeaderRequired, key)
+		}
+
+		if _, exists := names[key]; !exists {
+			return fmt.Errorf(MsgTemplateColumnNotFound, key)
+		}
+	}
+	return nil
+}
+
+// validateTemplateFormat checks a given template type and parses a given template delimiter. An empty type
+// means that a type of every template file is determined by its extension
+func validateTemplateFormat(templateType string, delimiter string, header bool, result *ValidationResult) TemplateFormat {
+	if templateType != "" {
+		validateOneOf("Template type", templateType, templateTypes, result)
+	}
+
+	if templateType == TemplateTypeJSONL && header {
+		result.valid = false
+		result.errMessages = append(result.errMessages, MsgTemplateHeaderNotSupported)
+	}
+
+	var format = TemplateFormat{Type: templateType, Delimiter: DefaultTemplateDelimiter, Header: header}
+	if delimiter != "" {
+		var parsedDelimiter, errDelimiter = ParseTemplateDelimiter(delimiter)
+		if errDelimiter != nil {
+			result.valid = false
+			result.errMessages = append(result.errMessages, fmt.Sprintf(MsgTemplateDelimiterInvalidWithReason, delimiter, errDelimiter.Error()))
+		} else {
+			format.Delimiter = parsedDelimiter
+		}
+	}
+
+	return format
+}
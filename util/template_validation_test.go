@@ -0,0 +1,131 @@
+package util
+
+import (
+	"github.com/vkrava4/curlson/app"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateTemplateModeOnceWithNotEnoughLines(t *testing.T) {
+	var givenFoundTemplate = "test.file"
+	var testFileAbsPath, _ = filepath.Abs(givenFoundTemplate)
+	_ = ioutil.WriteFile(testFileAbsPath, []byte("1\n2\n3\n"), filesMode)
+	defer os.Remove(testFileAbsPath)
+
+	var appConf = &app.Configuration{}
+	var getValidator = &GetValidator{}
+	var validatorEntity = getValidator.AddRequestCount(2).
+		AddThreads(2).
+		AddUrl("http://localhost:8080/users/#T{0}").
+		AddTemplate(givenFoundTemplate).
+		AddTemplateMode(TemplateModeOnce, 0).
+		WithAppConfiguration(appConf).
+		Entity()
+
+	var actualValidationResult = validatorEntity.Validate()
+
+	var expectedWarnMessage = "Template mode 'once' provides only 3 line(s) for 4 request(s). Execution will stop once all lines are used"
+	if !actualValidationResult.valid || strings.Join(actualValidationResult.warnMessages, ",") != expectedWarnMessage {
+		t.Errorf("Unexpected validation result %v", actualValidationResult)
+	}
+
+	if len(appConf.Template.Sources) != 1 || appConf.Template.Sources[0].Mode != TemplateModeOnce {
+		t.Errorf("Unexpected app configuration result %v", appConf.Template)
+	}
+}
+
+func TestValidateInvalidTemplateMode(t *testing.T) {
+	var getValidator = &GetValidator{}
+	var validatorEntity = getValidator.AddRequestCount(1).
+		AddThreads(1).
+		AddUrl("http://localhost:8080").
+		AddTemplateMode("shuffle", 0).
+		WithAppConfiguration(&app.Configuration{}).
+		Entity()
+
+	var actualValidationResult = validatorEntity.Validate()
+
+	if actualValidationResult.valid || len(actualValidationResult.errMessages) != 1 {
+		t.Errorf("Unexpected validation result %v", actualValidationResult)
+	}
+}
+
+func TestValidateNamedPlaceholdersWithTemplateHeader(t *testing.T) {
+	var givenFoundTemplate = "test.file"
+	var testFileAbsPath, _ = filepath.Abs(givenFoundTemplate)
+	_ = ioutil.WriteFile(testFileAbsPath, []byte("user_id;query\n1;\"a;b\"\n2;c\n"), filesMode)
+	defer os.Remove(testFileAbsPath)
+
+	var expectations = map[bool]string{
+		true:  "",
+		false: "Placeholder key 'user_id' refers to a column by name, but template file has no header row. Use 'template-header' flag or a column index",
+	}
+
+	for givenHeader, expectedErrMessage := range expectations {
+		var appConf = &app.Configuration{}
+		var getValidator = &GetValidator{}
+		var validatorEntity = getValidator.AddRequestCount(1).
+			AddThreads(1).
+			AddUrl("http://localhost:8080/users/#T{user_id}?q=#TE{query}").
+			AddTemplate(givenFoundTemplate).
+			AddTemplateFormat("", ";", givenHeader).
+			WithAppConfiguration(appConf).
+			Entity()
+
+		var actualValidationResult = validatorEntity.Validate()
+
+		if strings.Join(actualValidationResult.errMessages, ",") != expectedErrMessage {
+			t.Errorf("Unexpected validation result %v for header %v", actualValidationResult, givenHeader)
+		}
+
+		if givenHeader && (!appConf.Template.Enabled || templateSource(appConf.Template, "").Size != 2 || templateSource(appConf.Template, "").Delimiter != ';') {
+			t.Errorf("Unexpected app configuration result %v", appConf.Template)
+		}
+	}
+}
+
+func TestValidateJSONLinesTemplateWithMissingFields(t *testing.T) {
+	var givenFoundTemplate = "test.jsonl"
+	var testFileAbsPath, _ = filepath.Abs(givenFoundTemplate)
+	_ = ioutil.WriteFile(testFileAbsPath, []byte("{\"user\":{\"id\":1,\"zip\":\"01001\"}}\n{\"user\":{\"id\":2}}\n{\"user\":{\"id\":3,\"zip\":\"02002\"}}\n"), filesMode)
+	defer os.Remove(testFileAbsPath)
+
+	var appConf = &app.Configuration{}
+	var getValidator = &GetValidator{}
+	var validatorEntity = getValidator.AddRequestCount(1).
+		AddThreads(1).
+		AddUrl("http://localhost:8080/users/#T{user.id}?zip=#T{user.zip}").
+		AddTemplate(givenFoundTemplate).
+		WithAppConfiguration(appConf).
+		Entity()
+
+	var actualValidationResult = validatorEntity.Validate()
+
+	var expectedErrMessage = "Template line 2 is invalid. Reason: there is no value for placeholder key 'user.zip'"
+	if actualValidationResult.valid || strings.Join(actualValidationResult.errMessages, ",") != expectedErrMessage {
+		t.Errorf("Unexpected validation result %v", actualValidationResult)
+	}
+
+	if appConf.Template.Enabled || len(appConf.Template.Sources) > 0 {
+		t.Errorf("Unexpected app configuration result %v", appConf.Template)
+	}
+}
+
+func TestValidateUrlWithUnknownFunction(t *testing.T) {
+	var getValidator = &GetValidator{}
+	var validatorEntity = getValidator.AddRequestCount(1).
+		AddThreads(1).
+		AddUrl("http://localhost:8080/users/#F{uid}").
+		WithAppConfiguration(&app.Configuration{}).
+		Entity()
+
+	var actualValidationResult = validatorEntity.Validate()
+
+	var expectedErrMessage = "Template function '#F{uid}' is invalid. Reason: unknown function 'uid'"
+	if actualValidationResult.valid || strings.Join(actualValidationResult.errMessages, ",") != expectedErrMessage {
+		t.Errorf("Unexpected validation result %v", actualValidationResult)
+	}
+}
@@ -0,0 +1,207 @@
+package util
+
+import (
+	"fmt"
+	"github.com/vkrava4/curlson/app"
+)
+
+const (
+	// TemplateEngineCurlson renders requests with `#T{key}` placeholders and `#F{...}` template functions
+	TemplateEngineCurlson = "curlson"
+
+	// TemplateEngineGo renders requests with Go text/template
+	TemplateEngineGo = "go"
+)
+
+var templateEngines = []string{TemplateEngineCurlson, TemplateEngineGo}
+
+// RenderedRequest holds request parts with all placeholders resolved
+type RenderedRequest struct {
+	URL     string
+	Headers []app.Header
+	Body    []byte
+}
+
+// RequestRenderer renders URL, header values and body of every request with a template record and a function context
+type RequestRenderer interface {
+
+	// Render resolves request parts with a given template record, which is nil without a template file, and a function context
+	Render(record TemplateRecord, context *FunctionContext) (*RenderedRequest, error)
+
+	// ForThread returns a renderer to be used by a single thread. It may keep per-thread state, e.g. variables
+	ForThread() RequestRenderer
+}
+
+// NewRequestRenderer creates a renderer of a given URL and a request configuration filled by validation
+func NewRequestRenderer(url string, request *app.RequestConfiguration) RequestRenderer {
+	if request.GoTemplate != nil {
+		return newGoTemplateRenderer(url, request.GoTemplate, request.Headers, request.Body != nil)
+	}
+
+	return &templatedRequest{url: url, headers: request.Headers, body: request.Body, bodyTemplated: request.BodyTemplated}
+}
+
+// requestTemplate is a renderer which can be validated against a template file
+type requestTemplate interface {
+	RequestRenderer
+
+	// templateURL returns a URL template for validation messages
+	templateURL() string
+
+	// containsTemplatePlaceholders returns a boolean indicating whether any of request parts refers to template values
+	containsTemplatePlaceholders() bool
+
+	// placeholderKeys returns keys of placeholders which can be checked against template files before rendering
+	placeholderKeys() []string
+
+	// recordValidator returns a function which checks whether request parts can be resolved with a given template record.
+	// The function is created once per template file, so it's expected to be called for every template line
+	recordValidator() func(record TemplateRecord) error
+
+	// validateWithoutRecord checks whether request parts can be resolved without a template file
+	validateWithoutRecord(result *ValidationResult)
+}
+
+// templatedRequest holds request parts which may contain template placeholders: URL, header values and body
+type templatedRequest struct {
+	url           string
+	headers       []app.Header
+	body          []byte
+	bodyTemplated bool
+}
+
+// Render resolves template functions and then placeholders of every request part
+func (r *templatedRequest) Render(record TemplateRecord, context *FunctionContext) (*RenderedRequest, error) {
+	var requestUrl, errPrepareUrl = PrepareUrl(ResolveFunctions(r.url, context), record)
+	if errPrepareUrl != nil {
+		return nil, errPrepareUrl
+	}
+
+	var rendered = &RenderedRequest{URL: requestUrl, Headers: make([]app.Header, 0, len(r.headers)), Body: r.body}
+	for _, header := range r.headers {
+		var value, errPrepareHeader = PrepareHeaderValue(ResolveFunctions(header.Value, context), record)
+		if errPrepareHeader != nil {
+			return nil, errPrepareHeader
+		}
+		rendered.Headers = append(rendered.Headers, app.Header{Name: header.Name, Value: value})
+	}
+
+	if r.bodyTemplated {
+		var body, errPrepareBody = PrepareBody([]byte(ResolveFunctions(string(r.body), context)), record)
+		if errPrepareBody != nil {
+			return nil, errPrepareBody
+		}
+		rendered.Body = body
+	}
+
+	return rendered, nil
+}
+
+// ForThread returns the same renderer as it has no state
+func (r *templatedRequest) ForThread() RequestRenderer {
+	return r
+}
+
+func (r *templatedRequest) templateURL() string {
+	return r.url
+}
+
+// containsTemplatePlaceholders returns a boolean indicating whether any of request parts contains placeholders
+func (r *templatedRequest) containsTemplatePlaceholders() bool {
+	if ContainsTemplatePlaceholders(r.url) || ContainsTemplatePlaceholders(string(r.body)) {
+		return true
+	}
+
+	for _, header := range r.headers {
+		if ContainsTemplatePlaceholders(header.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+// placeholderKeys returns keys of placeholders of every request part
+func (r *templatedRequest) placeholderKeys() []string {
+	var keys = append(TemplatePlaceholderKeys(r.url), TemplatePlaceholderKeys(string(r.body))...)
+	for _, header := range r.headers {
+		keys = append(keys, TemplatePlaceholderKeys(header.Value)...)
+	}
+	return keys
+}
+
+func (r *templatedRequest) recordValidator() func(record TemplateRecord) error {
+	var keys = r.placeholderKeys()
+	return func(record TemplateRecord) error {
+		if errKeys := validateRecordKeys(record, keys); errKeys != nil {
+			return errKeys
+		}
+
+		return r.prepare(record)
+	}
+}
+
+func (r *templatedRequest) validateWithoutRecord(result *ValidationResult) {
+	var _, errPrepareUrl = PrepareUrl(r.url, nil)
+	if errPrepareUrl != nil {
+		result.valid = false
+		result.errMessages = append(result.errMessages, fmt.Sprintf(MsgURLAddressInvalidWithReason, r.url, errPrepareUrl.Error()))
+	}
+
+	for _, header := range r.headers {
+		if _, errPrepareHeader := PrepareHeaderValue(header.Value, nil); errPrepareHeader != nil {
+			result.valid = false
+			result.errMessages = append(result.errMessages, fmt.Sprintf(MsgHeaderInvalidWithReason, header.Name, errPrepareHeader.Error()))
+		}
+	}
+
+	if ContainsTemplatePlaceholders(string(r.body)) {
+		result.valid = false
+		result.errMessages = append(result.errMessages, fmt.Sprintf(MsgBodyInvalidWithReason, "it has placeholders but a template is not provided"))
+	}
+}
+
+// prepare resolves placeholders of every request part with values of a given record and returns the first occurred error
+func (r *templatedRequest) prepare(record TemplateRecord) error {
+	if _, errPrepareUrl := PrepareUrl(r.url, record); errPrepareUrl != nil {
+		return errPrepareUrl
+	}
+
+	for _, header := range r.headers {
+		if _, errPrepareHeader := PrepareHeaderValue(header.Value, record); errPrepareHeader != nil {
+			return errPrepareHeader
+		}
+	}
+
+	var _, errPrepareBody = PrepareBody(r.body, record)
+	return errPrepareBody
+}
+
+// validateFunctions checks template functions of every request part and returns the first occurred error
+func (r *templatedRequest) validateFunctions() error {
+	if errURL := ValidateFunctions(r.url); errURL != nil {
+		return errURL
+	}
+
+	for _, header := range r.headers {
+		if errHeader := ValidateFunctions(header.Value); errHeader != nil {
+			return errHeader
+		}
+	}
+
+	return ValidateFunctions(string(r.body))
+}
+
+// resolveFunctions returns a copy of the request with template functions of every part resolved with a given context
+func (r *templatedRequest) resolveFunctions(context *FunctionContext) *templatedRequest {
+	var resolved = &templatedRequest{
+		url:           ResolveFunctions(r.url, context),
+		headers:       make([]app.Header, 0, len(r.headers)),
+		body:          []byte(ResolveFunctions(string(r.body), context)),
+		bodyTemplated: r.bodyTemplated,
+	}
+
+	for _, header := range r.headers {
+		resolved.headers = append(resolved.headers, app.Header{Name: header.Name, Value: ResolveFunctions(header.Value, context)})
+	}
+	return resolved
+}
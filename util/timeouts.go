@@ -0,0 +1,63 @@
+package util
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseTimeout parses a timeout given as a duration, e.g. '1.5s' or '500ms', or as a curl-like number of seconds,
+// e.g. '2.5'. Zero means that there is no timeout
+func ParseTimeout(value string) (time.Duration, error) {
+	var trimmed = strings.TrimSpace(value)
+	if seconds, errNumber := strconv.ParseFloat(trimmed, 64); errNumber == nil {
+		if seconds < 0 || math.IsInf(seconds, 0) || math.IsNaN(seconds) {
+			return 0, fmt.Errorf("'%s' should be a positive number of seconds or zero", trimmed)
+		}
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+
+	var timeout, errParse = time.ParseDuration(trimmed)
+	if errParse != nil || timeout < 0 {
+		return 0, fmt.Errorf("'%s' should be a positive duration like '500ms' or '2s', a number of seconds or zero", trimmed)
+	}
+	return timeout, nil
+}
+
+// validateTimeouts parses given connect, TLS handshake, response header and total timeouts of requests
+// and stores them into app configuration
+func validateTimeouts(connect string, tls string, responseHeader string, maxTime string, result *ValidationResult) {
+	var timeouts = []struct {
+		description string
+		value       string
+		parsed      time.Duration
+	}{
+		{description: "connect timeout", value: connect},
+		{description: "TLS timeout", value: tls},
+		{description: "response header timeout", value: responseHeader},
+		{description: "max time", value: maxTime},
+	}
+
+	for i := range timeouts {
+		if timeouts[i].value == "" {
+			continue
+		}
+
+		var timeout, errParse = ParseTimeout(timeouts[i].value)
+		if errParse != nil {
+			result.valid = false
+			result.errMessages = append(result.errMessages, fmt.Sprintf(MsgTimeoutInvalidWithReason, timeouts[i].description, timeouts[i].value, errParse.Error()))
+			continue
+		}
+		timeouts[i].parsed = timeout
+	}
+
+	if result.conf != nil {
+		result.conf.Request.Timeouts.Connect = timeouts[0].parsed
+		result.conf.Request.Timeouts.TLS = timeouts[1].parsed
+		result.conf.Request.Timeouts.ResponseHeader = timeouts[2].parsed
+		result.conf.Request.Timeouts.Total = timeouts[3].parsed
+	}
+}
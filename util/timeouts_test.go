@@ -0,0 +1,66 @@
+package util
+
+import (
+	"github.com/vkrava4/curlson/app"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseTimeout(t *testing.T) {
+	var expectations = map[string]time.Duration{"2s": 2 * time.Second, "500ms": 500 * time.Millisecond, "2.5": 2500 * time.Millisecond, " 0 ": 0}
+
+	for given, expected := range expectations {
+		if actual, errParse := ParseTimeout(given); errParse != nil || actual != expected {
+			t.Errorf("Unexpected timeout %s of '%s', error: %v", actual, given, errParse)
+		}
+	}
+
+	for _, given := range []string{"", "-1", "-1s", "soon"} {
+		if _, errParse := ParseTimeout(given); errParse == nil {
+			t.Errorf("An error is expected for timeout '%s'", given)
+		}
+	}
+}
+
+func TestValidateTimeouts(t *testing.T) {
+	var appConf = &app.Configuration{}
+	var getValidator = &GetValidator{}
+	var validatorEntity = getValidator.AddRequestCount(1).
+		AddThreads(1).
+		AddUrl("http://localhost:8080/users").
+		AddTimeouts("1s", "2", "0", "5s").
+		WithAppConfiguration(appConf).
+		Entity()
+
+	var actualValidationResult = validatorEntity.Validate()
+
+	if !actualValidationResult.valid {
+		t.Errorf("Unexpected validation result %v", actualValidationResult)
+	}
+
+	var expected = app.TimeoutConfiguration{Connect: time.Second, TLS: 2 * time.Second, Total: 5 * time.Second}
+	if appConf.Request.Timeouts != expected {
+		t.Errorf("Unexpected app configuration result %v", appConf.Request.Timeouts)
+	}
+}
+
+func TestValidateInvalidTimeouts(t *testing.T) {
+	var getValidator = &GetValidator{}
+	var validatorEntity = getValidator.AddRequestCount(1).
+		AddThreads(1).
+		AddUrl("http://localhost:8080/users").
+		AddTimeouts("fast", "10s", "-1", "").
+		WithAppConfiguration(&app.Configuration{}).
+		Entity()
+
+	var actualValidationResult = validatorEntity.Validate()
+
+	var expectedErrMessages = []string{
+		"Provided connect timeout 'fast' is invalid. Reason: 'fast' should be a positive duration like '500ms' or '2s', a number of seconds or zero",
+		"Provided response header timeout '-1' is invalid. Reason: '-1' should be a positive number of seconds or zero",
+	}
+	if actualValidationResult.valid || strings.Join(actualValidationResult.errMessages, ",") != strings.Join(expectedErrMessages, ",") {
+		t.Errorf("Unexpected validation result %v", actualValidationResult)
+	}
+}
@@ -0,0 +1,160 @@
+package util
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"github.com/vkrava4/curlson/app"
+	"github.com/vkrava4/curlson/stats"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"time"
+)
+
+const (
+	// ConnectionPoolShared is a connection pool shared by all threads
+	ConnectionPoolShared = "shared"
+
+	// ConnectionPoolPerThread is a separate connection pool of every thread, so threads never reuse connections of each other
+	ConnectionPoolPerThread = "per-thread"
+)
+
+// ConnectionPools is a list of supported connection pools
+var ConnectionPools = []string{ConnectionPoolShared, ConnectionPoolPerThread}
+
+// validateTransport validates given options of connections of requests and stores them into app configuration.
+// An empty pool means the shared one
+func validateTransport(keepAlive bool, pool string, maxConnectionsPerHost int, idleTimeout string, newConnectionPerRequest bool, result *ValidationResult) {
+	if pool == "" {
+		pool = ConnectionPoolShared
+	}
+
+	validateOneOf("Connection pool", pool, ConnectionPools, result)
+	validatePositiveOrZero("Max connections per host", maxConnectionsPerHost, result)
+
+	var parsedIdleTimeout time.Duration
+	if idleTimeout != "" {
+		var errParse error
+		if parsedIdleTimeout, errParse = ParseTimeout(idleTimeout); errParse != nil {
+			result.valid = false
+			result.errMessages = append(result.errMessages, fmt.Sprintf(MsgTimeoutInvalidWithReason, "idle timeout", idleTimeout, errParse.Error()))
+		}
+	}
+
+	if newConnectionPerRequest && !keepAlive {
+		result.warnMessages = append(result.warnMessages, MsgNewConnectionWithoutKeepAlive)
+	}
+
+	if result.conf != nil {
+		result.conf.Request.Transport.DisableKeepAlives = !keepAlive
+		result.conf.Request.Transport.Pool = pool
+		result.conf.Request.Transport.MaxConnectionsPerHost = maxConnectionsPerHost
+		result.conf.Request.Transport.IdleTimeout = parsedIdleTimeout
+		result.conf.Request.Transport.NewConnectionPerRequest = newConnectionPerRequest
+	}
+}
+
+// NewHTTPClients creates HTTP clients of a given number of threads with given timeouts and connection options.
+// All threads share a single client and its pool of connections unless 'per-thread' connection pool is configured
+func NewHTTPClients(threads int, timeouts app.TimeoutConfiguration, options app.TransportConfiguration) []*http.Client {
+	var clients = make([]*http.Client, threads)
+	var perThread = options.Pool == ConnectionPoolPerThread
+	for i := range clients {
+		switch {
+		case perThread:
+			clients[i] = NewHTTPClient(timeouts, options, 1)
+		case i == 0:
+			clients[i] = NewHTTPClient(timeouts, options, threads)
+		default:
+			clients[i] = clients[0]
+		}
+	}
+
+	return clients
+}
+
+// NewHTTPClient creates an HTTP client with a dedicated transport which applies given connect, TLS handshake and response
+// header timeouts and given connection options. A pool of the transport keeps up to a given number of idle connections
+// per host unless connections per host are limited. Other settings of the transport are the same as of http.DefaultTransport.
+// A total timeout is applied to every request separately
+func NewHTTPClient(timeouts app.TimeoutConfiguration, options app.TransportConfiguration, idleConnections int) *http.Client {
+	var dialer = &net.Dialer{Timeout: timeouts.Connect, KeepAlive: 30 * time.Second}
+	var transport = http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	transport.TLSHandshakeTimeout = timeouts.TLS
+	transport.ResponseHeaderTimeout = timeouts.ResponseHeader
+
+	transport.DisableKeepAlives = options.DisableKeepAlives || options.NewConnectionPerRequest
+	transport.MaxConnsPerHost = options.MaxConnectionsPerHost
+	transport.IdleConnTimeout = options.IdleTimeout
+	transport.MaxIdleConns = 0
+	transport.MaxIdleConnsPerHost = idleConnections
+	if options.MaxConnectionsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = options.MaxConnectionsPerHost
+	}
+
+	// HTTP/2 multiplexes all requests over a single connection, so connections can't be closed or split by threads
+	if transport.DisableKeepAlives || options.Pool == ConnectionPoolPerThread {
+		transport.ForceAttemptHTTP2 = false
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+		if transport.TLSClientConfig != nil {
+			transport.TLSClientConfig.NextProtos = nil
+		}
+	}
+
+	return &http.Client{Transport: transport}
+}
+
+// SendRequest sends a given request with a given client and reads its response. The request is cancelled once a given
+// context is done or a given total timeout is over if it's positive, the latter is reported as ErrorClassMaxTime.
+// It returns a result with a status code, read bytes, an error and a connection of the request, other fields are
+// up to a caller, and false if the request was cancelled by the context, e.g. at the end of execution
+func SendRequest(ctx context.Context, client *http.Client, request *http.Request, total time.Duration) (stats.Result, bool) {
+	var requestCtx, cancelRequest = newRequestContext(ctx, total)
+	defer cancelRequest()
+
+	var result stats.Result
+	var response, errResponse = client.Do(request.WithContext(TraceConnection(requestCtx, &result.Connection)))
+	if errResponse == nil {
+		result.StatusCode = response.StatusCode
+		result.Bytes, errResponse = io.Copy(ioutil.Discard, response.Body)
+		_ = response.Body.Close()
+	}
+
+	if errResponse != nil && ctx.Err() != nil {
+		return result, false
+	}
+
+	result.Err = errResponse
+	if errResponse != nil && requestCtx.Err() == context.DeadlineExceeded {
+		result.ErrorClass = stats.ErrorClassMaxTime
+	}
+
+	return result, true
+}
+
+// newRequestContext creates a context of a single request which is done after a given total timeout if it's positive
+func newRequestContext(ctx context.Context, total time.Duration) (context.Context, context.CancelFunc) {
+	if total > 0 {
+		return context.WithTimeout(ctx, total)
+	}
+
+	return context.WithCancel(ctx)
+}
+
+// TraceConnection returns a context of a request which stores into a given connection whether the request
+// was sent over a new or a reused connection
+func TraceConnection(ctx context.Context, connection *stats.Connection) context.Context {
+	return httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			if info.Reused {
+				*connection = stats.ConnectionReused
+			} else {
+				*connection = stats.ConnectionNew
+			}
+		},
+	})
+}
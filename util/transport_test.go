@@ -0,0 +1,256 @@
+package util
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"github.com/vkrava4/curlson/app"
+	"github.com/vkrava4/curlson/stats"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateTransport(t *testing.T) {
+	var appConf = &app.Configuration{}
+	var getValidator = &GetValidator{}
+	var validatorEntity = getValidator.AddRequestCount(1).
+		AddThreads(10).
+		AddUrl("http://localhost:8080/users").
+		AddTransport(true, ConnectionPoolPerThread, 4, "30s", false).
+		WithAppConfiguration(appConf).
+		Entity()
+
+	var actualValidationResult = validatorEntity.Validate()
+
+	if !actualValidationResult.valid || len(actualValidationResult.warnMessages) != 0 {
+		t.Errorf("Unexpected validation result %v", actualValidationResult)
+	}
+
+	var expected = app.TransportConfiguration{Pool: ConnectionPoolPerThread, MaxConnectionsPerHost: 4, IdleTimeout: 30 * time.Second}
+	if appConf.Request.Transport != expected {
+		t.Errorf("Unexpected app configuration result %v", appConf.Request.Transport)
+	}
+}
+
+func TestValidateTransportWithoutKeepAlive(t *testing.T) {
+	var appConf = &app.Configuration{}
+	var getValidator = &GetValidator{}
+	var validatorEntity = getValidator.AddRequestCount(1).
+		AddThreads(1).
+		AddUrl("http://localhost:8080/users").
+		AddTransport(false, "", 0, "", true).
+		WithAppConfiguration(appConf).
+		Entity()
+
+	var actualValidationResult = validatorEntity.Validate()
+
+	if !actualValidationResult.valid || strings.Join(actualValidationResult.warnMessages, ",") != MsgNewConnectionWithoutKeepAlive {
+		t.Errorf("Unexpected validation result %v", actualValidationResult)
+	}
+
+	if !appConf.Request.Transport.DisableKeepAlives || appConf.Request.Transport.Pool != ConnectionPoolShared {
+		t.Errorf("Unexpected app configuration result %v", appConf.Request.Transport)
+	}
+}
+
+func TestValidateInvalidTransport(t *testing.T) {
+	var getValidator = &GetValidator{}
+	var validatorEntity = getValidator.AddRequestCount(1).
+		AddThreads(1).
+		AddUrl("http://localhost:8080/users").
+		AddTransport(true, "global", -1, "later", false).
+		WithAppConfiguration(&app.Configuration{}).
+		Entity()
+
+	var actualValidationResult = validatorEntity.Validate()
+
+	var expectedErrMessages = []string{
+		"Connection pool should be one of: shared, per-thread. Currently it's: 'global'",
+		"Max connections per host should be positive or equal to zero. Currently it's: '-1'",
+		"Provided idle timeout 'later' is invalid. Reason: 'later' should be a positive duration like '500ms' or '2s', a number of seconds or zero",
+	}
+	if actualValidationResult.valid || strings.Join(actualValidationResult.errMessages, ",") != strings.Join(expectedErrMessages, ",") {
+		t.Errorf("Unexpected validation result %v", actualValidationResult)
+	}
+}
+
+func givenConnections(t *testing.T, client *http.Client, url string, requests int) ([]stats.Connection, []int) {
+	var connections []stats.Connection
+	var protocols []int
+	for i := 0; i < requests; i++ {
+		var connection stats.Connection
+		var request, _ = http.NewRequest(http.MethodGet, url, nil)
+		var response, errDo = client.Do(request.WithContext(TraceConnection(context.Background(), &connection)))
+		if errDo != nil {
+			t.Fatalf("An error is not expected: %v", errDo)
+		}
+		_, _ = io.Copy(ioutil.Discard, response.Body)
+		_ = response.Body.Close()
+
+		connections = append(connections, connection)
+		protocols = append(protocols, response.ProtoMajor)
+	}
+
+	return connections, protocols
+}
+
+// givenHTTP2Server starts a TLS server which negotiates HTTP/2 with clients supporting it
+func givenHTTP2Server(t *testing.T) (string, func()) {
+	var certificateServer = httptest.NewTLSServer(http.NotFoundHandler())
+	var certificates = certificateServer.TLS.Certificates
+	certificateServer.Close()
+
+	var listener, errListen = net.Listen("tcp", "127.0.0.1:0")
+	if errListen != nil {
+		t.Fatalf("An error is not expected: %v", errListen)
+	}
+
+	var server = &http.Server{
+		Handler:   http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+		TLSConfig: &tls.Config{Certificates: certificates},
+	}
+	go func() {
+		_ = server.ServeTLS(listener, "", "")
+	}()
+
+	return "https://" + listener.Addr().String(), func() { _ = server.Close() }
+}
+
+func trustAnyCertificate(client *http.Client) {
+	var transport = client.Transport.(*http.Transport)
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	transport.TLSClientConfig.InsecureSkipVerify = true
+}
+
+func TestNewHTTPClientReusesConnectionsWithKeepAlive(t *testing.T) {
+	var server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	var client = NewHTTPClient(app.TimeoutConfiguration{}, app.TransportConfiguration{}, 1)
+	var connections, _ = givenConnections(t, client, server.URL, 3)
+
+	var expected = []stats.Connection{stats.ConnectionNew, stats.ConnectionReused, stats.ConnectionReused}
+	if fmt.Sprint(connections) != fmt.Sprint(expected) {
+		t.Errorf("Unexpected connections %v, expected: %v", connections, expected)
+	}
+}
+
+func TestNewHTTPClientOpensNewConnectionsWithoutKeepAlive(t *testing.T) {
+	var server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	for _, options := range []app.TransportConfiguration{{DisableKeepAlives: true}, {NewConnectionPerRequest: true}} {
+		var client = NewHTTPClient(app.TimeoutConfiguration{}, options, 1)
+		var connections, _ = givenConnections(t, client, server.URL, 3)
+
+		var expected = []stats.Connection{stats.ConnectionNew, stats.ConnectionNew, stats.ConnectionNew}
+		if fmt.Sprint(connections) != fmt.Sprint(expected) {
+			t.Errorf("Unexpected connections %v of options %+v, expected: %v", connections, options, expected)
+		}
+	}
+}
+
+func TestNewHTTPClientDisablesHTTP2(t *testing.T) {
+	var url, closeServer = givenHTTP2Server(t)
+	defer closeServer()
+
+	var expectations = []struct {
+		options  app.TransportConfiguration
+		protocol int
+	}{
+		{options: app.TransportConfiguration{}, protocol: 2},
+		{options: app.TransportConfiguration{DisableKeepAlives: true}, protocol: 1},
+		{options: app.TransportConfiguration{NewConnectionPerRequest: true}, protocol: 1},
+		{options: app.TransportConfiguration{Pool: ConnectionPoolPerThread}, protocol: 1},
+	}
+
+	for _, expectation := range expectations {
+		var client = NewHTTPClient(app.TimeoutConfiguration{}, expectation.options, 1)
+		trustAnyCertificate(client)
+
+		var _, protocols = givenConnections(t, client, url, 1)
+		if protocols[0] != expectation.protocol {
+			t.Errorf("Unexpected HTTP/%d protocol of options %+v, expected: HTTP/%d", protocols[0], expectation.options, expectation.protocol)
+		}
+	}
+}
+
+func TestNewHTTPClients(t *testing.T) {
+	var shared = NewHTTPClients(3, app.TimeoutConfiguration{}, app.TransportConfiguration{Pool: ConnectionPoolShared})
+	if shared[0] != shared[1] || shared[0] != shared[2] || shared[0].Transport.(*http.Transport).MaxIdleConnsPerHost != 3 {
+		t.Errorf("Threads should share a single client keeping an idle connection of every thread")
+	}
+
+	var perThread = NewHTTPClients(3, app.TimeoutConfiguration{}, app.TransportConfiguration{Pool: ConnectionPoolPerThread})
+	if perThread[0] == perThread[1] || perThread[1] == perThread[2] || perThread[0].Transport.(*http.Transport).MaxIdleConnsPerHost != 1 {
+		t.Errorf("Every thread should have its own client keeping a single idle connection")
+	}
+
+	var limited = NewHTTPClients(3, app.TimeoutConfiguration{}, app.TransportConfiguration{Pool: ConnectionPoolPerThread, MaxConnectionsPerHost: 2})
+	if transport := limited[0].Transport.(*http.Transport); transport.MaxIdleConnsPerHost != 2 || transport.MaxConnsPerHost != 2 {
+		t.Errorf("Idle connections should follow the limit of connections per host")
+	}
+}
+
+func TestSendRequest(t *testing.T) {
+	var server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte("hello"))
+	}))
+	defer server.Close()
+
+	var request, _ = http.NewRequest(http.MethodGet, server.URL, nil)
+	var result, completed = SendRequest(context.Background(), server.Client(), request, time.Second)
+
+	if !completed || result.Err != nil || result.StatusCode != http.StatusAccepted || result.Bytes != 5 || result.Connection != stats.ConnectionNew {
+		t.Errorf("Unexpected result %+v, completed: %v", result, completed)
+	}
+}
+
+func TestSendRequestExceedingMaxTime(t *testing.T) {
+	var server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+
+	var request, _ = http.NewRequest(http.MethodGet, server.URL, nil)
+	var result, completed = SendRequest(context.Background(), server.Client(), request, 50*time.Millisecond)
+	if !completed || result.Err == nil {
+		t.Fatalf("Request exceeding max time should be completed with an error, actual: %+v", result)
+	}
+
+	var recorder = stats.NewRecorder()
+	recorder.Record(result)
+	recorder.Stop()
+
+	var errors = recorder.Summary().Errors
+	if len(errors) != 1 || errors[0].Class != stats.ErrorClassMaxTime {
+		t.Errorf("Request exceeding max time should be recorded with '%s' error class, actual: %v", stats.ErrorClassMaxTime, errors)
+	}
+}
+
+func TestSendRequestCancelledByContext(t *testing.T) {
+	var server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	var ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	var request, _ = http.NewRequest(http.MethodGet, server.URL, nil)
+	if result, completed := SendRequest(ctx, server.Client(), request, time.Second); completed {
+		t.Errorf("Request cancelled by the context should not be completed, actual: %+v", result)
+	}
+}
@@ -1,6 +1,8 @@
 package util
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/url"
@@ -9,23 +11,18 @@ import (
 )
 
 var (
-	templateEscapedRegex   = "(\\#TE{\\d+})"
-	templateUnescapedRegex = "(\\#T{\\d+})"
+	// templatePlaceholderRegex matches `#T{key}`, `#TE{key}` and `#TJ{key}` placeholders where a key is either
+	// an index of a value or a name of its column
+	templatePlaceholderRegex     = regexp.MustCompile(`#T(E|J)?\{([A-Za-z0-9_.\-]+)\}`)
+	templatePlaceholderNameRegex = regexp.MustCompile(`^[A-Za-z0-9_.\-]+$`)
 )
 
-// Prepares given url param (URL address) by replacing placeholder values: `#TE{i}` or `#T{i}` with given `valuesLine[i]` value
-// #TE{i} - Template Escaped, means that its values will be escaped so it can be safely placed inside a URL path segment. See: url.QueryEscape(value)
-// #T{i} - Template Unescaped, means that its values will be placed to resulted URL in a raw format
-func PrepareUrl(urlTemplate string, valuesLine string) (string, error) {
-	var values = strings.Split(valuesLine, ",")
-	var valuesLen = len(values)
-
-	if valuesLen != 0 {
-		for i, value := range values {
-			urlTemplate = strings.ReplaceAll(urlTemplate, fmt.Sprintf("#TE{%d}", i), url.QueryEscape(value))
-			urlTemplate = strings.ReplaceAll(urlTemplate, fmt.Sprintf("#T{%d}", i), value)
-		}
-	}
+// Prepares given url param (URL address) by replacing placeholder values: `#TE{key}` or `#T{key}` with a value of a given record.
+// A key is either an index of a value, e.g. `#T{0}`, or a name of its column in a template header, e.g. `#T{user_id}`
+// #TE{key} - Template Escaped, means that its values will be escaped so it can be safely placed inside a URL path segment. See: url.QueryEscape(value)
+// #T{key} - Template Unescaped, means that its values will be placed to resulted URL in a raw format
+func PrepareUrl(urlTemplate string, record TemplateRecord) (string, error) {
+	urlTemplate = ReplacePlaceholders(urlTemplate, record)
 
 	if ContainsTemplatePlaceholders(urlTemplate) {
 		return "", errors.New(fmt.Sprintf("Given URL: '%s' has unresolved placeholders", urlTemplate))
@@ -34,6 +31,52 @@ func PrepareUrl(urlTemplate string, valuesLine string) (string, error) {
 	return ParseAndValidateUrl(urlTemplate)
 }
 
+// Replaces placeholder values: `#TE{key}`, `#TJ{key}` or `#T{key}` of a given text with values of a given record. See PrepareUrl
+// #TJ{key} - Template JSON escaped, means that its values will be escaped so it can be safely placed inside a JSON string literal.
+// Placeholders without a corresponding value are left untouched
+func ReplacePlaceholders(text string, record TemplateRecord) string {
+	if record == nil {
+		return text
+	}
+
+	return templatePlaceholderRegex.ReplaceAllStringFunc(text, func(placeholder string) string {
+		var match = templatePlaceholderRegex.FindStringSubmatch(placeholder)
+		var value, exists = record.Value(match[2])
+		if !exists {
+			return placeholder
+		}
+
+		switch match[1] {
+		case "E":
+			return url.QueryEscape(value)
+		case "J":
+			return JSONEscape(value)
+		default:
+			return value
+		}
+	})
+}
+
+// TemplatePlaceholderKeys returns keys of all placeholders of a given text in order of their appearance
+func TemplatePlaceholderKeys(text string) []string {
+	var keys []string
+	for _, match := range templatePlaceholderRegex.FindAllStringSubmatch(text, -1) {
+		keys = append(keys, match[2])
+	}
+	return keys
+}
+
+// JSONEscape escapes a given value so it can be placed inside a JSON string literal. Surrounding quotes are not added
+func JSONEscape(value string) string {
+	var buffer = &bytes.Buffer{}
+	var encoder = json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(value)
+
+	var encoded = strings.TrimSuffix(buffer.String(), "\n")
+	return encoded[1 : len(encoded)-1]
+}
+
 func ParseAndValidateUrl(urlAddress string) (string, error) {
 	var parsedUrl, err = url.ParseRequestURI(urlAddress)
 	if err == nil && (parsedUrl.Scheme == "http" || parsedUrl.Scheme == "https") && parsedUrl.Host != "" {
@@ -43,12 +86,6 @@ func ParseAndValidateUrl(urlAddress string) (string, error) {
 	return "", errors.New(fmt.Sprintf("A string: '%s' is not valid URL", urlAddress))
 }
 
-func ContainsTemplatePlaceholders(urlAddress string) bool {
-	return containsTemplatePlaceholdersForRegex(templateEscapedRegex, urlAddress) ||
-		containsTemplatePlaceholdersForRegex(templateUnescapedRegex, urlAddress)
-}
-
-func containsTemplatePlaceholdersForRegex(regex string, urlAddress string) bool {
-	var match, _ = regexp.MatchString(regex, urlAddress)
-	return match
+func ContainsTemplatePlaceholders(text string) bool {
+	return templatePlaceholderRegex.MatchString(text)
 }
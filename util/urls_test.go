@@ -10,7 +10,7 @@ func TestPrepareUrlWithRegularAttributes(t *testing.T) {
 	var givenValueLine = "Test,Test2"
 
 	var expectedUrl = "http://localhost:8080/get/echo/with_status_param_and_request_param/Test?data=Test2"
-	var actualUrl, _ = PrepareUrl(givenUrlTemplate, givenValueLine)
+	var actualUrl, _ = PrepareUrl(givenUrlTemplate, givenTemplateRecord(givenValueLine))
 
 	if expectedUrl != actualUrl {
 		t.Errorf("PrepareUrl result is incorrect, actual: '%s', expected: '%s'", actualUrl, expectedUrl)
@@ -22,7 +22,7 @@ func TestNotPrepareUrlWithRegularAttributesIfContainsTemplatePlaceholders(t *tes
 	var givenValueLine = "Test,Test2"
 
 	var expectedUrlInError = "http://localhost:8080/get/echo/with_status_param_and_request_param/Test/Test/#T{2}?data=Test2"
-	var _, err = PrepareUrl(givenUrlTemplate, givenValueLine)
+	var _, err = PrepareUrl(givenUrlTemplate, givenTemplateRecord(givenValueLine))
 
 	if err != nil && err.Error() == fmt.Sprintf("Given URL: '%s' has unresolved placeholders", expectedUrlInError) {
 	} else {
@@ -35,7 +35,7 @@ func TestPrepareNonUrlWithRegularAndEscapedAttributes(t *testing.T) {
 	var givenValueLine = "test/200/123,T  H//I // S\\\\I \\ S++T + E   ST1"
 
 	var expectedErr = "A string: 'htp://localhost:8080/get/echo/with_status_param_and_request_param/test/200/123?data=T++H%2F%2FI+%2F%2F+S%5C%5CI+%5C+S%2B%2BT+%2B+E+++ST1' is not valid URL"
-	var _, err = PrepareUrl(givenUrlTemplate, givenValueLine)
+	var _, err = PrepareUrl(givenUrlTemplate, givenTemplateRecord(givenValueLine))
 
 	if err != nil && err.Error() == expectedErr {
 	} else {
@@ -48,7 +48,7 @@ func TestPrepareUrlWithRegularAndEscapedAttributes(t *testing.T) {
 	var givenValueLine = "test/200/123,T  H//I // S\\\\I \\ S++T + E   ST1"
 
 	var expectedUrl = "http://localhost:8080/get/echo/with_status_param_and_request_param/test/200/123?data=T++H%2F%2FI+%2F%2F+S%5C%5CI+%5C+S%2B%2BT+%2B+E+++ST1"
-	var actualUrl, _ = PrepareUrl(givenUrlTemplate, givenValueLine)
+	var actualUrl, _ = PrepareUrl(givenUrlTemplate, givenTemplateRecord(givenValueLine))
 
 	if expectedUrl != actualUrl {
 		t.Errorf("PrepareUrl result is incorrect, actual: '%s', expected: '%s'", actualUrl, expectedUrl)
@@ -103,3 +103,30 @@ func TestParseAndValidateUrlForInvalidStrings(t *testing.T) {
 		}
 	}
 }
+
+func TestReplacePlaceholdersWithJSONEscapedAttributes(t *testing.T) {
+	var givenTemplate = `{"name":"#TJ{0}","note":"#TJ{1}"}`
+	var givenValueLine = `"John ""Johnny"" Doe",a\b <tab>	end`
+
+	var expected = `{"name":"John \"Johnny\" Doe","note":"a\\b <tab>\tend"}`
+	var actual = ReplacePlaceholders(givenTemplate, givenTemplateRecord(givenValueLine))
+
+	if expected != actual {
+		t.Errorf("ReplacePlaceholders result is incorrect, actual: '%s', expected: '%s'", actual, expected)
+	}
+}
+
+func TestJSONEscape(t *testing.T) {
+	var expectations = map[string]string{
+		"plain":        "plain",
+		`quote "here"`: `quote \"here\"`,
+		"line\nbreak":  `line\nbreak`,
+		"<&>":          "<&>",
+	}
+
+	for given, expected := range expectations {
+		if actual := JSONEscape(given); actual != expected {
+			t.Errorf("JSONEscape result is incorrect for '%s', actual: '%s', expected: '%s'", given, actual, expected)
+		}
+	}
+}
@@ -10,6 +10,7 @@ const (
 	// Generic validation constants
 	MsgShouldBePositive       = "%s should be positive. Currently it's: '%d'"
 	MsgShouldBePositiveOrZero = "%s should be positive or equal to zero. Currently it's: '%d'"
+	MsgShouldBeOneOf          = "%s should be one of: %s. Currently it's: '%s'"
 
 	// URL-related validation constants
 	MsgURLAddressInvalidWithReason = "Provided URL address: '%s' is invalid. Reason: %s"
@@ -19,8 +20,72 @@ const (
 	MsgCantOpenTemplateWithReason    = "Provided template file '%s' can not be opened. Reason: %s"
 	MsgURLPlaceholdersNotFound       = "Given URL '%s' doesn't contain placeholders. Templating will be ignored"
 	MsgTemplateNotFound              = "Provided template file '%s' can not be found"
+	MsgTemplateOnceExhausted         = "Template mode 'once' provides only %d line(s) for %d request(s). Execution will stop once all lines are used"
+	MsgTemplatePerThreadTooSmall     = "Template mode 'per-thread' requires at least one line per thread. Template has %d line(s) for %d thread(s)"
+	MsgTemplateHeaderRequired        = "Placeholder key '%s' refers to a column by name, but template file has no header row. Use 'template-header' flag or a column index"
+	MsgTemplateColumnNotFound        = "Placeholder key '%s' doesn't match any column of template header row"
+
+	MsgTemplateDelimiterInvalidWithReason = "Provided template delimiter '%s' is invalid. Reason: %s"
+	MsgTemplateHeaderNotSupported         = "Template header row is supported by CSV templates only"
+	MsgTemplateLineInvalidWithReason      = "Template line %d is invalid. Reason: %s"
+	MsgTemplateMoreLinesInvalid           = "... and %d more invalid template line(s)"
+	MsgTemplateValueNotFound              = "there is no value for placeholder key '%s'"
+	MsgGoTemplateInvalidWithReason        = "Provided Go template is invalid. Reason: %s"
+	MsgTemplateEmpty                      = "Provided template file '%s' has no lines"
+
+	// Data source-related validation constants
+	MsgDataSourceInvalidWithReason = "Provided data source '%s' is invalid. Reason: %s"
+	MsgDataSourceDuplicate         = "Data source name '%s' is used more than once"
+	MsgDataModeInvalid             = "Provided data mode '%s' is invalid. Expected format is 'name=mode'"
+	MsgDataModeSourceNotFound      = "Data mode '%s' refers to unknown data source '%s'"
+	MsgDataSourcePrefix            = "Data source '%s': %s"
+
+	// Load-related validation constants
+	MsgRateInvalidWithReason      = "Provided rate '%s' is invalid. Reason: %s"
+	MsgSleepIgnoredWithRate       = "Delay in millis property is ignored when a rate is set as requests are sent on a fixed timetable"
+	MsgStageInvalidWithReason     = "Provided stage '%s' is invalid. Reason: %s"
+	MsgStagesWithRate             = "Stages can't be combined with a constant rate. Use 'stage-type' of 'rate' to change a rate over time"
+	MsgStagesTargetsZero          = "At least one stage should have a target greater than zero"
+	MsgCountIgnoredWithDuration   = "Amount of requests property is ignored when a duration is set as requests are sent until the duration is over"
+	MsgDurationInvalidWithReason  = "Provided duration '%s' is invalid. Reason: %s"
+	MsgDurationWithStages         = "Duration can't be combined with stages as execution lasts for a total duration of stages"
+	MsgThinkTimeInvalidWithReason = "Provided think time '%s' is invalid. Reason: %s"
+	MsgThinkTimeWithSleep         = "Think time can't be combined with delay in millis property"
+	MsgThinkTimeIgnoredWithRate   = "Think time is ignored when a rate is set as requests are sent on a fixed timetable"
+	MsgThinkTimeTemplateRequired  = "Think time '%s' takes values from a template, but template file is not set"
+	MsgCountIgnoredWithStages     = "Amount of requests property is ignored when stages are set as execution lasts for a total duration of stages"
+	MsgMaxRateInvalidWithReason   = "Provided max rate '%s' is invalid. Reason: %s"
+	MsgMaxRateWithStages          = "Max rate can't be combined with stages of 'max-rate' type as the stages change the max rate over time"
+	MsgControlAddressInvalid      = "Provided control address '%s' is invalid. Reason: %s"
+	MsgControlAddressWithoutRate  = "Control address requires a max rate which it changes"
+	MsgControlAddressWithStages   = "Control address can't be combined with stages of 'max-rate' type as the stages change the max rate over time"
+
+	// Request-related validation constants
+	MsgMethodInvalid = "Provided HTTP method '%s' is invalid. It should consist of letters, digits or any of: %s"
+
+	// Timeout-related validation constants
+	MsgTimeoutInvalidWithReason = "Provided %s '%s' is invalid. Reason: %s"
+
+	// Transport-related validation constants
+	MsgNewConnectionWithoutKeepAlive = "New connection per request is implied when keep-alive is disabled"
+
+	// Header-related validation constants
+	MsgHeaderInvalidWithReason   = "Provided header '%s' is invalid. Reason: %s"
+	MsgCantReadHeadersWithReason = "Provided headers file '%s' can not be read. Reason: %s"
+
+	// Body-related validation constants
+	MsgCantReadBodyWithReason = "Provided request body '%s' can not be read. Reason: %s"
+	MsgBodyInvalidWithReason  = "Provided request body is invalid. Reason: %s"
+
+	// Threshold-related validation constants
+	MsgThresholdInvalidWithReason = "Provided threshold '%s' is invalid. Reason: %s"
 )
 
+var outputFormats = []string{"json", "csv"}
+
+// maxReportedTemplateLines is a maximum number of invalid template lines reported by validation one by one
+const maxReportedTemplateLines = 10
+
 // Validator interface responsible for performing initial flags and templates validation
 type Validator interface {
 
@@ -52,6 +117,42 @@ type ValidatorEntity struct {
 	maxDuration  int
 	url          string
 	template     string
+	output       string
+	outputFormat string
+	thresholds   []string
+	method       string
+	headers      []string
+	headersFile  string
+	body         string
+	contentType  string
+	templateMode string
+	seed         int64
+	dataSources  []string
+	dataModes    []string
+	rate         string
+	stages       []string
+	stageType    string
+	duration     string
+	thinkTime    string
+	maxRate      string
+	maxRateBurst int
+	controlAddr  string
+
+	connectTimeout        string
+	tlsTimeout            string
+	responseHeaderTimeout string
+	maxTime               string
+
+	keepAlive               bool
+	connectionPool          string
+	maxConnectionsPerHost   int
+	idleTimeout             string
+	newConnectionPerRequest bool
+
+	templateEngine    string
+	templateType      string
+	templateDelimiter string
+	templateHeader    bool
 
 	conf *app.Configuration
 }